@@ -1,16 +1,16 @@
 package analysis
 
 import (
-	"bufio"
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
 	"go/ast"
 	"go/token"
-	"log"
-	"os"
-	"path/filepath"
+	"io"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/packages"
@@ -32,37 +32,291 @@ const loadMode = packages.NeedName |
 type Linter struct {
 	// The Name of the analyzer must be a valid Go identifier
 	Name string
-	// Run applies the analyzer to a package.
-	Run func(*config.Config, []*packages.Package) []Issue
+	// Doc is a short description of the check.
+	Doc string
+	// Settings return default settings of linter in config, default is `config.NewDefaultLinter`.
+	Settings func() config.LinterSettings
+	// Run applies the analyzer to packages.
+	Run func(*config.Config, []*Package) ([]Issue, error)
+}
+
+// Package loaded package with sources of running analysis, so hashs of issues are built
+// from content which was analyzed (e.g. unsaved buffers of editor).
+type Package struct {
+	*packages.Package
+	// Sources cache of source files of the run, files are read from disk if it is nil.
+	Sources *Sources
+}
+
+// Options of loading packages for analysis.
+type Options struct {
+	// Patterns of packages, default is `./...`.
+	Patterns []string
+	// Tests analyze test files. Linter can override it by `Info.Tests`.
+	Tests bool
+	// Jobs number of workers running (linter × package) units in parallel, default is GOMAXPROCS.
+	Jobs int
+	// Builds configurations for loading packages, issues of all builds are merged by hash.
+	// Default is one build with current environment.
+	Builds []config.Build
+	// Overlay contents of files by absolute path, e.g. unsaved buffers of editor (see packages.Config.Overlay).
+	Overlay map[string][]byte
+	// Log writer of verbose messages, e.g. number of skipped generated files. Messages are not written if it is nil.
+	Log io.Writer
 }
 
 // Run analyze source code.
-func Run(cfg *config.Config, linters ...*Linter) map[string][]Issue {
-	pkgs, err := packages.Load(&packages.Config{Mode: loadMode, Tests: false}, "./...")
-	if err != nil {
-		log.Fatalf("failed load go/packages: %s", err)
+// Packages with parse or type-check errors are not analyzed, in this case
+// issues of other packages are returned together with *BrokenPackagesError.
+// Exclusions which matched issues are tracked for this run only (see config.Config.IsUsed).
+func Run(cfg *config.Config, opts *Options, linters ...*Linter) (map[string][]Issue, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	cfg.ResetMatches()
+
+	builds := opts.Builds
+	if len(builds) == 0 {
+		builds = []config.Build{{}}
 	}
 
 	allIssues := make(map[string][]Issue, len(linters))
+	for _, linter := range linters {
+		allIssues[linter.Name] = make([]Issue, 0)
+	}
+
+	var diagnostics []Diagnostic
+
+	for _, build := range builds {
+		buildIssues, buildDiagnostics, err := runBuild(cfg, opts, build, linters)
+		if err != nil {
+			return nil, err
+		}
+
+		buildName := ""
+		if len(builds) > 1 {
+			buildName = build.String()
+			for i := range buildDiagnostics {
+				buildDiagnostics[i].Build = buildName
+			}
+		}
+
+		diagnostics = append(diagnostics, buildDiagnostics...)
+		MergeIssues(allIssues, buildIssues, buildName)
+	}
+
+	if len(diagnostics) != 0 {
+		return allIssues, &BrokenPackagesError{Diagnostics: diagnostics}
+	}
+
+	return allIssues, nil
+}
+
+func runBuild(cfg *config.Config, opts *Options, build config.Build, linters []*Linter) (map[string][]Issue, []Diagnostic, error) {
+	patterns := opts.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	files := newLinterFiles(cfg, opts.Tests, linters)
+
+	fset := token.NewFileSet()
+	sources := NewSources()
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:       loadMode,
+		Tests:      files.loadTests,
+		Env:        build.GetEnv(),
+		BuildFlags: build.GetBuildFlags(),
+		Fset:       fset,
+		ParseFile:  sources.ParseFile,
+		Overlay:    opts.Overlay,
+	}, patterns...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed load go/packages for build %s: %w", build, err)
+	}
+
+	pkgs, diagnostics := SplitBrokenPackages(pkgs)
+	pkgs = RemoveCgoGeneratedFiles(pkgs)
+
+	allIssues, err := runLinters(cfg, linters, files.split(pkgs, linters, opts.Log, build), sources, opts.Jobs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return allIssues, diagnostics, nil
+}
+
+// linterFiles settings of linters which files of packages are analyzed (test and generated files).
+type linterFiles struct {
+	tests     map[string]bool
+	generated map[string]bool
+	// loadTests test files are analyzed by some linter.
+	loadTests bool
+	// removeGenerated generated files are skipped by some linter.
+	removeGenerated bool
+}
+
+// newLinterFiles return settings of files of linters by config, tests is default value of analyzing test files.
+func newLinterFiles(cfg *config.Config, tests bool, linters []*Linter) *linterFiles {
+	files := &linterFiles{
+		tests:     make(map[string]bool, len(linters)),
+		generated: make(map[string]bool, len(linters)),
+	}
 
 	for _, linter := range linters {
-		allIssues[linter.Name] = linter.Run(cfg, pkgs)
+		files.tests[linter.Name] = tests
+		if value := cfg.GetInfo(linter.Name).Tests; value != nil {
+			files.tests[linter.Name] = *value
+		}
+		files.loadTests = files.loadTests || files.tests[linter.Name]
+
+		files.generated[linter.Name] = cfg.IsIncludeGenerated(linter.Name)
+		files.removeGenerated = files.removeGenerated || !files.generated[linter.Name]
+	}
+
+	return files
+}
+
+// split return packages for every linter without test files and generated files which are skipped by linter,
+// number of skipped generated files is written to log.
+func (f *linterFiles) split(pkgs []*packages.Package, linters []*Linter, log io.Writer, build config.Build) [][]*packages.Package {
+	var pkgsWithoutTests []*packages.Package
+	if f.loadTests {
+		pkgs = DeduplicateTestPackages(pkgs)
+		pkgsWithoutTests = RemoveTestFiles(pkgs)
+	}
+
+	var pkgsWithoutGenerated, pkgsWithoutTestsAndGenerated []*packages.Package
+	if f.removeGenerated {
+		var skipped []string
+		pkgsWithoutGenerated, skipped = RemoveGeneratedFiles(pkgs)
+		if f.loadTests {
+			pkgsWithoutTestsAndGenerated, _ = RemoveGeneratedFiles(pkgsWithoutTests)
+		}
+
+		if log != nil {
+			fmt.Fprintf(log, "golimiter: skipped %d generated and vendor files (build %s)\n", len(skipped), build)
+		}
+	}
+
+	pkgsByLinter := make([][]*packages.Package, len(linters))
+	for i, linter := range linters {
+		withTests := !f.loadTests || f.tests[linter.Name]
+
+		switch {
+		case withTests && f.generated[linter.Name]:
+			pkgsByLinter[i] = pkgs
+		case withTests:
+			pkgsByLinter[i] = pkgsWithoutGenerated
+		case f.generated[linter.Name]:
+			pkgsByLinter[i] = pkgsWithoutTests
+		default:
+			pkgsByLinter[i] = pkgsWithoutTestsAndGenerated
+		}
+	}
+
+	return pkgsByLinter
+}
+
+// runLinters run each linter for each package in pool of workers with sources of the run,
+// order of issues is the same as in sequential run.
+func runLinters(cfg *config.Config, linters []*Linter, pkgsByLinter [][]*packages.Package, sources *Sources, jobs int) (map[string][]Issue, error) {
+	type task struct {
+		linter int
+		pkg    int
+	}
+
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	issues := make([][][]Issue, len(linters))
+	errs := make([][]error, len(linters))
+	for i := range linters {
+		issues[i] = make([][]Issue, len(pkgsByLinter[i]))
+		errs[i] = make([]error, len(pkgsByLinter[i]))
+	}
+
+	tasks := make(chan task)
+
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			for t := range tasks {
+				pkgs := []*Package{{Package: pkgsByLinter[t.linter][t.pkg], Sources: sources}}
+				issues[t.linter][t.pkg], errs[t.linter][t.pkg] = linters[t.linter].Run(cfg, pkgs)
+			}
+			wg.Done()
+		}()
+	}
+
+	for i := range linters {
+		for j := range pkgsByLinter[i] {
+			tasks <- task{linter: i, pkg: j}
+		}
+	}
+	close(tasks)
+
+	wg.Wait()
+
+	allIssues := make(map[string][]Issue, len(linters))
+
+	for i, linter := range linters {
+		allIssues[linter.Name] = make([]Issue, 0)
+
+		for j := range pkgsByLinter[i] {
+			if err := errs[i][j]; err != nil {
+				return nil, fmt.Errorf("failed run linter %s: %w", linter.Name, err)
+			}
+			allIssues[linter.Name] = append(allIssues[linter.Name], issues[i][j]...)
+		}
 	}
 
-	return allIssues
+	return allIssues, nil
+}
+
+// MergeIssues add issues of build to all issues, same issues are merged by hash and message (see Issue.getKey).
+// If build is not empty, it is added to `Issue.Builds`.
+func MergeIssues(allIssues, buildIssues map[string][]Issue, build string) {
+	for linter, issues := range buildIssues {
+		index := make(map[string]int, len(allIssues[linter]))
+		for i, issue := range allIssues[linter] {
+			index[issue.getKey()] = i
+		}
+
+		for _, issue := range issues {
+			i, ok := index[issue.getKey()]
+			if !ok {
+				i = len(allIssues[linter])
+				index[issue.getKey()] = i
+				allIssues[linter] = append(allIssues[linter], issue)
+			}
+
+			if build != "" {
+				allIssues[linter][i].Builds = append(allIssues[linter][i].Builds, build)
+			}
+		}
+	}
 }
 
-func GetHashFromBody(fset *token.FileSet, node ast.Node) string {
-	filename := fset.Position(node.Pos()).Filename
+func GetHashFromBody(pkg *Package, node ast.Node) string {
+	filename := pkg.Fset.Position(node.Pos()).Filename
 	filename = GetPathRelative(filename)
 
-	b, err := os.ReadFile(filename)
-	if err != nil {
+	src, file := pkg.Sources.GetSource(pkg.Fset, node.Pos())
+	if file == nil {
+		return ""
+	}
+
+	start, end := file.Offset(node.Pos()), file.Offset(node.End())
+	if end > len(src) || start > end {
 		return ""
 	}
 
-	body := b[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset]
-	body = append(body, []byte(filename)...)
+	body := slices.Concat(src[start:end], []byte(filename))
 	return GetHashFromBytes(body)
 }
 
@@ -75,41 +329,21 @@ func GetHashFromBytes(object []byte) string {
 	return hex.EncodeToString(hash[:])
 }
 
-func GetHashFromBodyByLine(fset *token.FileSet, node ast.Node, line int) string {
-	filename := fset.Position(node.Pos()).Filename
+func GetHashFromBodyByLine(pkg *Package, node ast.Node, line int) string {
+	filename := pkg.Fset.Position(node.Pos()).Filename
 	filename = GetPathRelative(filename)
 
-	file, err := os.Open(filename)
-	if err != nil {
+	text, ok := pkg.Sources.GetSourceLine(pkg.Fset, node.Pos(), line)
+	if !ok {
 		return ""
 	}
 
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	i := 0
-	for scanner.Scan() {
-		i++
-		if i != line {
-			continue
-		}
-
-		body := fmt.Sprintf("%s_%s", strings.TrimSpace(scanner.Text()), filename)
-		return GetHashFromString(body)
-	}
-
-	return ""
+	body := fmt.Sprintf("%s_%s", strings.TrimSpace(text), filename)
+	return GetHashFromString(body)
 }
 
+// GetPathRelative return path relative to root dir of module (see config.GetRootDir).
+// If path can't be made relative, it is returned as is.
 func GetPathRelative(path string) string {
-	dir, err := os.Getwd()
-	if err != nil {
-		panic(err)
-	}
-
-	path, err = filepath.Rel(dir, path)
-	if err != nil {
-		panic(err)
-	}
-	return path
+	return config.GetPathRelative(path)
 }
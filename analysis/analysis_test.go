@@ -0,0 +1,146 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// newPackages return packages with paths from 0 to count.
+func newPackages(count int) []*packages.Package {
+	pkgs := make([]*packages.Package, count)
+	for i := range pkgs {
+		pkgs[i] = &packages.Package{PkgPath: fmt.Sprintf("example.com/pkg%d", i)}
+	}
+	return pkgs
+}
+
+// newPathLinter return linter which report issue for path of every package, slower for the first packages.
+func newPathLinter(name, failOn string) *Linter {
+	return &Linter{
+		Name: name,
+		Run: func(_ *config.Config, pkgs []*Package) ([]Issue, error) {
+			var issues []Issue
+			for _, pkg := range pkgs {
+				var i int
+				_, _ = fmt.Sscanf(pkg.PkgPath, "example.com/pkg%d", &i)
+				time.Sleep(time.Duration(10-i) * time.Millisecond)
+
+				if pkg.PkgPath == failOn {
+					return nil, errors.New(pkg.PkgPath)
+				}
+				issues = append(issues, Issue{Message: name, Filename: pkg.PkgPath, Hash: GetHashFromString(name + pkg.PkgPath)})
+			}
+			return issues, nil
+		},
+	}
+}
+
+func TestRunLintersJobs(t *testing.T) {
+	linters := []*Linter{newPathLinter("NoDefer", ""), newPathLinter("NoInit", "")}
+	pkgs := newPackages(10)
+
+	sequential, err := runLinters(&config.Config{}, linters, [][]*packages.Package{pkgs, pkgs[:5]}, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, sequential["NoDefer"], 10)
+	require.Len(t, sequential["NoInit"], 5)
+
+	parallel, err := runLinters(&config.Config{}, linters, [][]*packages.Package{pkgs, pkgs[:5]}, nil, 8)
+	require.NoError(t, err)
+	require.Equal(t, sequential, parallel)
+}
+
+func TestRunLintersError(t *testing.T) {
+	// error of the later package is finished first, error of the first linter and package is returned.
+	linters := []*Linter{newPathLinter("NoDefer", "example.com/pkg2"), newPathLinter("NoInit", "example.com/pkg9")}
+	pkgs := newPackages(10)
+
+	for _, jobs := range []int{1, 8} {
+		_, err := runLinters(&config.Config{}, linters, [][]*packages.Package{pkgs, pkgs}, nil, jobs)
+		require.EqualError(t, err, "failed run linter NoDefer: example.com/pkg2")
+	}
+}
+
+// deferLinter report every `defer` in packages.
+var deferLinter = &Linter{
+	Name: "NoDefer",
+	Run: func(_ *config.Config, pkgs []*Package) ([]Issue, error) {
+		var issues []Issue
+		for _, pkg := range pkgs {
+			for _, file := range pkg.Syntax {
+				ast.Inspect(file, func(node ast.Node) bool {
+					if node, ok := node.(*ast.DeferStmt); ok {
+						position := pkg.Fset.Position(node.Pos())
+						issues = append(issues, Issue{
+							Message:  "defer",
+							Filename: position.Filename,
+							Line:     position.Line,
+							Hash:     GetFingerprint("NoDefer", pkg, node),
+						})
+					}
+					return true
+				})
+			}
+		}
+		return issues, nil
+	},
+}
+
+func TestRunBuilds(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":       "module example.com/app\n\ngo 1.23\n",
+		"app.go":       "package app\n\nfunc A() { defer println() }\n",
+		"app_linux.go": "package app\n\nfunc B() { defer println() }\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	opts := &Options{Builds: []config.Build{{Name: "linux", GOOS: "linux"}, {Name: "windows", GOOS: "windows"}}}
+
+	allIssues, err := Run(&config.Config{}, opts, deferLinter)
+	require.NoError(t, err)
+
+	builds := make(map[string][]string)
+	for _, issue := range allIssues["NoDefer"] {
+		builds[filepath.Base(issue.Filename)] = issue.Builds
+	}
+
+	// issue of both builds is merged, issue of file for GOOS is reported only for its build.
+	require.Equal(t, map[string][]string{"app.go": {"linux", "windows"}, "app_linux.go": {"linux"}}, builds)
+}
+
+func TestMergeIssues(t *testing.T) {
+	length := Issue{Message: "length", Filename: "a.go", Line: 3, Hash: "c4ca4238a0b923820dcc509a6f75849b"}
+	segments := Issue{Message: "segments", Filename: "a.go", Line: 3, Hash: "c4ca4238a0b923820dcc509a6f75849b"}
+	pkgIssue := Issue{Message: "package", Filename: "a.go", Line: 1, Hash: "5a90af01712c1c2960f886090e112ec6", Package: true}
+	otherPkgIssue := Issue{Message: "package", Filename: "b.go", Line: 1, Hash: "5a90af01712c1c2960f886090e112ec6", Package: true}
+
+	// issue with other line in other build is merged by hash.
+	shifted := length
+	shifted.Line = 5
+
+	allIssues := map[string][]Issue{}
+	MergeIssues(allIssues, map[string][]Issue{"NoLength": {length, segments, pkgIssue, otherPkgIssue}}, "linux")
+	MergeIssues(allIssues, map[string][]Issue{"NoLength": {shifted, pkgIssue}}, "windows")
+
+	require.Len(t, allIssues["NoLength"], 4)
+	require.Equal(t, []string{"linux", "windows"}, allIssues["NoLength"][0].Builds)
+	require.Equal(t, []string{"linux"}, allIssues["NoLength"][1].Builds)
+	require.Equal(t, []string{"linux", "windows"}, allIssues["NoLength"][2].Builds)
+	require.Equal(t, []string{"linux"}, allIssues["NoLength"][3].Builds)
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// RemoveCgoGeneratedFiles return copies of packages without files generated by cgo
+// (e.g. `_cgo_gotypes.go`). Files processed by cgo are kept, positions of their
+// nodes are mapped to source files by `//line` directives.
+func RemoveCgoGeneratedFiles(pkgs []*packages.Package) []*packages.Package {
+	res := make([]*packages.Package, 0, len(pkgs))
+
+	for _, pkg := range pkgs {
+		if slices.Equal(pkg.GoFiles, pkg.CompiledGoFiles) {
+			res = append(res, pkg)
+			continue
+		}
+
+		clone := *pkg
+		clone.Syntax = clone.Syntax[:0:0]
+
+		for _, file := range pkg.Syntax {
+			if slices.Contains(pkg.GoFiles, pkg.Fset.Position(file.Package).Filename) {
+				clone.Syntax = append(clone.Syntax, file)
+			}
+		}
+
+		res = append(res, &clone)
+	}
+
+	return res
+}
+
+// IsCgoGeneratedFile check file is generated by cgo and has no source file,
+// e.g. `_cgo_gotypes.go` or file in build cache without extension.
+func IsCgoGeneratedFile(filename string) bool {
+	return strings.HasPrefix(filepath.Base(filename), "_cgo_") || filepath.Ext(filename) != ".go"
+}
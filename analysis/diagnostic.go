@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Diagnostic problem in loading package (list, parse or type-check error).
+type Diagnostic struct {
+	Package  string `json:"package"`
+	Position string `json:"position"`
+	Message  string `json:"message"`
+	Kind     string `json:"kind"`
+	Build    string `json:"build,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	position := d.Position
+	if position == "" {
+		position = d.Package
+	}
+
+	if d.Build != "" {
+		return fmt.Sprintf("%s: %s [%s]", position, d.Message, d.Build)
+	}
+	return fmt.Sprintf("%s: %s", position, d.Message)
+}
+
+// BrokenPackagesError packages that were not analyzed because of errors.
+type BrokenPackagesError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *BrokenPackagesError) Error() string {
+	lines := make([]string, 0, len(e.Diagnostics))
+	for _, diagnostic := range e.Diagnostics {
+		lines = append(lines, diagnostic.String())
+	}
+	return fmt.Sprintf("packages with errors were not analyzed:\n%s", strings.Join(lines, "\n"))
+}
+
+// SplitBrokenPackages split packages to valid and diagnostics of packages with errors.
+func SplitBrokenPackages(pkgs []*packages.Package) ([]*packages.Package, []Diagnostic) {
+	var diagnostics []Diagnostic
+
+	valid := make([]*packages.Package, 0, len(pkgs))
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) == 0 {
+			valid = append(valid, pkg)
+			continue
+		}
+
+		// package is not a part of current build configuration, e.g. file `//go:build windows` for GOOS=linux.
+		if len(pkg.Errors) == 1 && strings.Contains(pkg.Errors[0].Msg, "build constraints exclude all Go files") {
+			continue
+		}
+
+		for _, err := range pkg.Errors {
+			diagnostics = append(diagnostics, Diagnostic{
+				Package:  pkg.PkgPath,
+				Position: err.Pos,
+				Message:  err.Msg,
+				Kind:     GetErrorKind(err.Kind),
+			})
+		}
+	}
+
+	return valid, diagnostics
+}
+
+// GetErrorKind return name of kind error in go/packages.
+func GetErrorKind(kind packages.ErrorKind) string {
+	switch kind {
+	case packages.ListError:
+		return "list"
+	case packages.ParseError:
+		return "parse"
+	case packages.TypeError:
+		return "type"
+	}
+	return "unknown"
+}
@@ -0,0 +1,187 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// FingerprintVersion version of scheme of fingerprints.
+//
+// Fingerprint is used as `Hash` of issue and in `ExcludeHashs`, it is md5 of fields separated by zero byte:
+//
+//   - version of scheme;
+//   - name of linter;
+//   - path of package, e.g. `github.com/mirecl/golimiter/analysis`;
+//   - enclosing declaration of node: `Func`, `(Type).Method`, name of type, var or const
+//     (empty for issues of package or file);
+//   - normalised node: tokens of source joined by space (comments and trailing commas are skipped),
+//     words of text for comments or value for issues without node (e.g. name of file).
+//
+// So fingerprint survives formatting, line shifts and moving of file in package, and is changed
+// when package is moved or enclosing declaration is renamed. Identical nodes in the same
+// declaration are distinguished by index of occurrence in order of source (see `Occurrences`).
+const FingerprintVersion = "v2"
+
+// Occurrences count fingerprints of issues of linter in package, so identical nodes
+// in the same declaration get different fingerprints.
+type Occurrences map[string]int
+
+// Get return fingerprint with index of occurrence, the first occurrence keeps fingerprint,
+// e.g. the second `defer mu.Unlock()` in func gets md5 of fingerprint and index 1.
+func (o Occurrences) Get(fingerprint string) string {
+	index := o[fingerprint]
+	o[fingerprint]++
+
+	if index == 0 {
+		return fingerprint
+	}
+
+	return GetHashFromString(fingerprint + "\x00" + strconv.Itoa(index))
+}
+
+// GetFingerprint return fingerprint of issue for node.
+func GetFingerprint(linter string, pkg *Package, node ast.Node) string {
+	return GetFingerprintByPos(linter, pkg, node.Pos(), GetNormalizedNode(pkg, node))
+}
+
+// GetFingerprintByPos return fingerprint of issue for normalised value, pos is used
+// to find enclosing declaration (token.NoPos for issues of package or file).
+func GetFingerprintByPos(linter string, pkg *Package, pos token.Pos, value string) string {
+	fields := []string{FingerprintVersion, linter, pkg.PkgPath, GetEnclosingDecl(pkg, pos), value}
+	return GetHashFromString(strings.Join(fields, "\x00"))
+}
+
+// GetEnclosingDecl return name of top-level declaration which contains pos,
+// e.g. `Func`, `(Type).Method` or name of type, var or const.
+func GetEnclosingDecl(pkg *Package, pos token.Pos) string {
+	if !pos.IsValid() {
+		return ""
+	}
+
+	for _, file := range pkg.Syntax {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			if pos < decl.Pos() || pos >= decl.End() {
+				continue
+			}
+
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				return getFuncName(decl)
+			case *ast.GenDecl:
+				return getSpecName(decl, pos)
+			}
+		}
+	}
+
+	return ""
+}
+
+// GetNormalizedNode return tokens of node joined by space, comments, trailing commas and positions are skipped.
+// Text of comment is normalised by words.
+func GetNormalizedNode(pkg *Package, node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.Comment:
+		return NormalizeText(node.Text)
+	case *ast.CommentGroup:
+		return NormalizeText(node.Text())
+	case *ast.Ident:
+		return node.Name
+	}
+
+	src, file := pkg.Sources.GetSource(pkg.Fset, node.Pos())
+	if file == nil {
+		return ""
+	}
+
+	start, end := file.Offset(node.Pos()), file.Offset(node.End())
+	if end > len(src) || start > end {
+		return ""
+	}
+
+	var s scanner.Scanner
+	s.Init(token.NewFileSet().AddFile("", -1, end-start), src[start:end], nil, 0)
+
+	var tokens []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		// semicolons are inserted by newlines.
+		if tok == token.SEMICOLON {
+			continue
+		}
+
+		// trailing commas are added by splitting of lists on lines.
+		if (tok == token.RPAREN || tok == token.RBRACE || tok == token.RBRACK) && len(tokens) != 0 && tokens[len(tokens)-1] == "," {
+			tokens = tokens[:len(tokens)-1]
+		}
+
+		if lit == "" {
+			lit = tok.String()
+		}
+		tokens = append(tokens, lit)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// NormalizeText return words of text joined by space.
+func NormalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func getFuncName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+
+	recv := decl.Recv.List[0].Type
+	for {
+		switch expr := recv.(type) {
+		case *ast.StarExpr:
+			recv = expr.X
+			continue
+		case *ast.IndexExpr:
+			recv = expr.X
+			continue
+		case *ast.IndexListExpr:
+			recv = expr.X
+			continue
+		case *ast.Ident:
+			return "(" + expr.Name + ")." + decl.Name.Name
+		}
+		return decl.Name.Name
+	}
+}
+
+func getSpecName(decl *ast.GenDecl, pos token.Pos) string {
+	for _, spec := range decl.Specs {
+		if pos < spec.Pos() || pos >= spec.End() {
+			continue
+		}
+
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			return spec.Name.Name
+		case *ast.ValueSpec:
+			names := make([]string, 0, len(spec.Names))
+			for _, name := range spec.Names {
+				names = append(names, name.Name)
+			}
+			return strings.Join(names, ",")
+		case *ast.ImportSpec:
+			return spec.Path.Value
+		}
+	}
+
+	return decl.Tok.String()
+}
@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// parseFingerprints return fingerprints of `defer` statements of source.
+func parseFingerprints(t *testing.T, src string) []string {
+	filename := filepath.Join(t.TempDir(), "app.go")
+	require.NoError(t, os.WriteFile(filename, []byte(src), 0o600))
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	require.NoError(t, err)
+
+	pkg := &Package{Package: &packages.Package{PkgPath: "example.com/app", Fset: fset, Syntax: []*ast.File{file}}}
+
+	var fingerprints []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if stmt, ok := node.(*ast.DeferStmt); ok {
+			fingerprints = append(fingerprints, GetFingerprint("NoDefer", pkg, stmt))
+		}
+		return true
+	})
+
+	return fingerprints
+}
+
+func TestGetFingerprint(t *testing.T) {
+	fingerprints := parseFingerprints(t, `package app
+
+type T struct{}
+
+func (t *T) A() {
+	defer println(1)
+}
+
+func B() { defer println(1) }
+`)
+
+	// reformatted, shifted and commented source.
+	shifted := parseFingerprints(t, `package app
+
+// C is added before.
+func C() {}
+
+type T struct{}
+
+func B() {
+	defer println( // comment
+		1,
+	)
+}
+
+func (t *T) A() {
+
+	defer   println(1)
+}
+`)
+
+	require.Len(t, fingerprints, 2)
+	require.NotEqual(t, fingerprints[0], fingerprints[1])
+	require.Equal(t, []string{fingerprints[1], fingerprints[0]}, shifted)
+}
+
+func TestGetEnclosingDecl(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "app.go", `package app
+
+var a, b = 1, 2
+
+type L[T any] []T
+
+func (l *L[T]) Len() int { return len(*l) }
+`, 0)
+	require.NoError(t, err)
+
+	pkg := &Package{Package: &packages.Package{Fset: fset, Syntax: []*ast.File{file}}}
+
+	var decls []string
+	for _, decl := range file.Decls {
+		decls = append(decls, GetEnclosingDecl(pkg, decl.End()-1))
+	}
+
+	require.Equal(t, []string{"a,b", "L", "(L).Len"}, decls)
+	require.Empty(t, GetEnclosingDecl(pkg, token.NoPos))
+}
+
+func TestOccurrences(t *testing.T) {
+	fingerprints := parseFingerprints(t, `package app
+
+func A() {
+	defer println(1)
+	defer println(1)
+}
+`)
+
+	occurrences := Occurrences{}
+	first, second := occurrences.Get(fingerprints[0]), occurrences.Get(fingerprints[1])
+
+	require.Equal(t, fingerprints[0], fingerprints[1])
+	require.Equal(t, fingerprints[0], first)
+	require.NotEqual(t, first, second)
+	require.Equal(t, second, Occurrences{fingerprints[0]: 1}.Get(fingerprints[0]))
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// RemoveGeneratedFiles return copies of packages without generated files and files of `vendor` (see IsGeneratedFile),
+// packages which contain only such files are removed. Skipped files are returned sorted without duplicates.
+func RemoveGeneratedFiles(pkgs []*packages.Package) ([]*packages.Package, []string) {
+	res := make([]*packages.Package, 0, len(pkgs))
+	var skipped []string
+
+	for _, pkg := range pkgs {
+		generated := make(map[string]bool)
+		for _, file := range pkg.Syntax {
+			filename := pkg.Fset.Position(file.Package).Filename
+			if IsGeneratedFile(pkg.Fset, file) {
+				generated[filename] = true
+				skipped = append(skipped, filename)
+			}
+		}
+
+		if len(generated) == 0 {
+			res = append(res, pkg)
+			continue
+		}
+
+		clone := *pkg
+		clone.GoFiles = removeFilenames(pkg.GoFiles, generated)
+		clone.CompiledGoFiles = removeFilenames(pkg.CompiledGoFiles, generated)
+		clone.Syntax = clone.Syntax[:0:0]
+
+		for _, file := range pkg.Syntax {
+			if !generated[pkg.Fset.Position(file.Package).Filename] {
+				clone.Syntax = append(clone.Syntax, file)
+			}
+		}
+
+		if len(clone.GoFiles) == 0 {
+			continue
+		}
+
+		res = append(res, &clone)
+	}
+
+	slices.Sort(skipped)
+
+	return res, slices.Compact(skipped)
+}
+
+// IsGeneratedFile check file has header of generated code (`// Code generated ... DO NOT EDIT.`)
+// or is in folder `vendor`. Files of cgo are parsed from generated files with `//line` directives
+// of source file, so header is checked only if parsed file is the source file.
+func IsGeneratedFile(fset *token.FileSet, file *ast.File) bool {
+	filename := fset.Position(file.Package).Filename
+	if IsVendorFile(filename) {
+		return true
+	}
+
+	return ast.IsGenerated(file) && fset.File(file.Package).Name() == filename
+}
+
+// IsVendorFile check file (path relative to root of module) is in folder `vendor`.
+func IsVendorFile(filename string) bool {
+	return slices.Contains(strings.Split(filepath.ToSlash(GetPathRelative(filename)), "/"), "vendor")
+}
+
+func removeFilenames(filenames []string, removed map[string]bool) []string {
+	res := make([]string, 0, len(filenames))
+	for _, filename := range filenames {
+		if !removed[filename] {
+			res = append(res, filename)
+		}
+	}
+	return res
+}
@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestRemoveGeneratedFiles(t *testing.T) {
+	fset := token.NewFileSet()
+
+	sources := map[string]string{
+		"app/app.go":        "package app\n",
+		"app/app_gen.go":    "// Code generated by mockgen. DO NOT EDIT.\n\npackage app\n",
+		"app/comment.go":    "package app\n\n// Code generated by mockgen. DO NOT EDIT.\n",
+		"vendor/lib/lib.go": "package lib\n",
+	}
+
+	pkgs := make([]*packages.Package, 0, 2)
+	for _, files := range [][]string{{"app/app.go", "app/app_gen.go", "app/comment.go"}, {"vendor/lib/lib.go"}} {
+		pkg := &packages.Package{Fset: fset, GoFiles: files, CompiledGoFiles: files}
+		for _, filename := range files {
+			file, err := parser.ParseFile(fset, filename, sources[filename], parser.ParseComments)
+			require.NoError(t, err)
+			pkg.Syntax = append(pkg.Syntax, file)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+
+	res, skipped := RemoveGeneratedFiles(pkgs)
+
+	require.Equal(t, []string{"app/app_gen.go", "vendor/lib/lib.go"}, skipped)
+	require.Len(t, res, 1)
+	require.Equal(t, []string{"app/app.go", "app/comment.go"}, res[0].GoFiles)
+	require.Len(t, res[0].Syntax, 2)
+	require.Len(t, pkgs[0].Syntax, 3)
+
+	// cgo file is parsed from generated file with `//line` directive of source file.
+	file, err := parser.ParseFile(fset, "cache/cg.cgo1.go", "// Code generated by cmd/cgo; DO NOT EDIT.\n\n//line app/cg.go:1:1\npackage app\n", parser.ParseComments)
+	require.NoError(t, err)
+	require.False(t, IsGeneratedFile(fset, file))
+	require.True(t, IsGeneratedFile(fset, pkgs[0].Syntax[1]))
+}
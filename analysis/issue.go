@@ -1,5 +1,10 @@
 package analysis
 
+import (
+	"fmt"
+	"go/token"
+)
+
 // Issue problem in analysis.
 type Issue struct {
 	Message  string `json:"message"`
@@ -8,4 +13,26 @@ type Issue struct {
 	Hash     string `json:"hash"`
 	Severity string `json:"severity"`
 	Type     string `json:"type"`
+	// Builds names of build configurations where issue was found (only for several builds).
+	Builds []string `json:"builds,omitempty"`
+	// LegacyHash hash of issue by previous scheme, it is used to migrate `ExcludeHashs` to fingerprints.
+	LegacyHash string `json:"-"`
+	// Package issue is reported for whole package or file (e.g. by NoObject or NoUnderscore), not for line of code.
+	Package bool `json:"-"`
+	// Pos position of issue in file set of package (token.NoPos for issues of package or file),
+	// it keeps column and raw position of files with `//line` directives (e.g. cgo) for analyzers.
+	Pos token.Pos `json:"-"`
+}
+
+// getKey return key of issue for merging builds by hash, so issue is merged if lines differ between builds.
+// Message is a part of key, because one node can have several issues (e.g. length and segments in NoLength),
+// issues of package have the same hash in every file and issues without hash are kept by position.
+func (i Issue) getKey() string {
+	switch {
+	case i.Hash == "":
+		return fmt.Sprintf("%s_%d_%s", i.Filename, i.Line, i.Message)
+	case i.Package:
+		return fmt.Sprintf("%s_%s_%s", i.Hash, i.Filename, i.Message)
+	}
+	return fmt.Sprintf("%s_%s", i.Hash, i.Message)
 }
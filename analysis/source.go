@@ -0,0 +1,107 @@
+package analysis
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sync"
+)
+
+// Sources cache of source files parsed by go/packages, key is token.File.
+// It is filled by ParseFile during loading packages and is safe for concurrent use.
+type Sources struct {
+	mu    sync.RWMutex
+	files map[*token.File][]byte
+}
+
+// NewSources create empty cache of source files.
+func NewSources() *Sources {
+	return &Sources{files: make(map[*token.File][]byte)}
+}
+
+// ParseFile parse file and save its source, it is used as `packages.Config.ParseFile`.
+func (s *Sources) ParseFile(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	// same mode as default in go/packages, linters use resolution of objects.
+	const mode = parser.AllErrors | parser.ParseComments
+
+	if src == nil {
+		var err error
+		if src, err = os.ReadFile(filename); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := parser.ParseFile(fset, filename, src, mode)
+	if file != nil {
+		if tokFile := fset.File(file.Package); tokFile != nil {
+			s.mu.Lock()
+			s.files[tokFile] = src
+			s.mu.Unlock()
+		}
+	}
+
+	return file, err
+}
+
+// Get return source of file.
+func (s *Sources) Get(file *token.File) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	src, ok := s.files[file]
+	return src, ok
+}
+
+// GetSource return source of file which contains pos. Source is taken from cache,
+// otherwise (e.g. for nil cache of analyzers) it is read from disk.
+// Offsets of pos are valid in returned source (also for files processed by cgo).
+func (s *Sources) GetSource(fset *token.FileSet, pos token.Pos) ([]byte, *token.File) {
+	file := fset.File(pos)
+	if file == nil {
+		return nil, nil
+	}
+
+	if s != nil {
+		if src, ok := s.Get(file); ok {
+			return src, file
+		}
+	}
+
+	src, err := os.ReadFile(file.Name())
+	if err != nil {
+		return nil, nil
+	}
+
+	return src, file
+}
+
+// GetSourceLine return line of source by number of line in file which contains pos,
+// number of line is adjusted by `//line` directives.
+func (s *Sources) GetSourceLine(fset *token.FileSet, pos token.Pos, line int) (string, bool) {
+	src, file := s.GetSource(fset, pos)
+	if file == nil {
+		return "", false
+	}
+
+	// find line in parsed file, it differs from line in position for files processed by cgo.
+	adjustedLine := fset.Position(pos).Line
+	line = file.Line(pos) + line - adjustedLine
+
+	if line < 1 || line > file.LineCount() {
+		return "", false
+	}
+
+	start := file.Offset(file.LineStart(line))
+	if start > len(src) {
+		return "", false
+	}
+
+	text := src[start:]
+	if end := bytes.IndexByte(text, '\n'); end != -1 {
+		text = text[:end]
+	}
+
+	return string(bytes.TrimSuffix(text, []byte("\r"))), true
+}
@@ -0,0 +1,133 @@
+package analysis
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// generateLargeFile write file with count funcs, each func has statement `defer`.
+func generateLargeFile(t testing.TB, count int) string {
+	var b strings.Builder
+
+	b.WriteString("package large\n\n")
+	for i := range count {
+		fmt.Fprintf(&b, "func F%d() {\n\tdefer println(%d) //nolint:errcheck\n}\n\n", i, i)
+	}
+
+	filename := filepath.Join(t.TempDir(), "large.go")
+	require.NoError(t, os.WriteFile(filename, []byte(b.String()), 0o600))
+
+	return filename
+}
+
+// parseLargeFile return package of large file without cache of sources, its cache and `defer` statements.
+func parseLargeFile(t testing.TB, count int) (*Package, *Sources, []ast.Node) {
+	fset := token.NewFileSet()
+	sources := NewSources()
+
+	file, err := sources.ParseFile(fset, generateLargeFile(t, count), nil)
+	require.NoError(t, err)
+
+	var nodes []ast.Node
+	ast.Inspect(file, func(node ast.Node) bool {
+		if stmt, ok := node.(*ast.DeferStmt); ok {
+			nodes = append(nodes, stmt)
+		}
+		return true
+	})
+	require.Len(t, nodes, count)
+
+	return &Package{Package: &packages.Package{Fset: fset}}, sources, nodes
+}
+
+func TestGetHashFromBodyWithSources(t *testing.T) {
+	pkg, sources, nodes := parseLargeFile(t, 10)
+	line := pkg.Fset.Position(nodes[3].Pos()).Line
+
+	withoutCache := GetHashFromBody(pkg, nodes[3])
+	withoutCacheByLine := GetHashFromBodyByLine(pkg, nodes[3], line)
+
+	cached := &Package{Package: pkg.Package, Sources: sources}
+
+	require.NotEmpty(t, withoutCache)
+	require.Equal(t, withoutCache, GetHashFromBody(cached, nodes[3]))
+	require.Equal(t, withoutCacheByLine, GetHashFromBodyByLine(cached, nodes[3], line))
+	require.NotEqual(t, GetHashFromBody(cached, nodes[3]), GetHashFromBody(cached, nodes[4]))
+
+	text, ok := sources.GetSourceLine(pkg.Fset, nodes[3].Pos(), line)
+	require.True(t, ok)
+	require.Equal(t, "\tdefer println(3) //nolint:errcheck", text)
+}
+
+func TestSourcesOfRun(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.go")
+	require.NoError(t, os.WriteFile(filename, []byte("package app\n\nfunc A() {}\n"), 0o600))
+
+	// sources of each run keep content which was parsed (e.g. overlay), other runs and disk are not affected.
+	var lines []string
+	for _, body := range []string{"package app\n\nfunc B() {}\n", "package app\n\nfunc C() {}\n"} {
+		fset := token.NewFileSet()
+		sources := NewSources()
+
+		file, err := sources.ParseFile(fset, filename, []byte(body))
+		require.NoError(t, err)
+
+		line, ok := sources.GetSourceLine(fset, file.Decls[0].Pos(), 3)
+		require.True(t, ok)
+		lines = append(lines, line)
+
+		line, ok = (*Sources)(nil).GetSourceLine(fset, file.Decls[0].Pos(), 3)
+		require.True(t, ok)
+		lines = append(lines, line)
+	}
+
+	require.Equal(t, []string{"func B() {}", "func A() {}", "func C() {}", "func A() {}"}, lines)
+}
+
+func BenchmarkGetHashFromBody(b *testing.B) {
+	for _, count := range []int{1_000, 10_000} {
+		pkg, sources, nodes := parseLargeFile(b, count)
+		cached := &Package{Package: pkg.Package, Sources: sources}
+
+		b.Run(fmt.Sprintf("disk/%d", count), func(b *testing.B) {
+			for i := range b.N {
+				GetHashFromBody(pkg, nodes[i%len(nodes)])
+			}
+		})
+
+		b.Run(fmt.Sprintf("cache/%d", count), func(b *testing.B) {
+			for i := range b.N {
+				GetHashFromBody(cached, nodes[i%len(nodes)])
+			}
+		})
+	}
+}
+
+func BenchmarkGetHashFromBodyByLine(b *testing.B) {
+	for _, count := range []int{1_000, 10_000} {
+		pkg, sources, nodes := parseLargeFile(b, count)
+		cached := &Package{Package: pkg.Package, Sources: sources}
+
+		b.Run(fmt.Sprintf("disk/%d", count), func(b *testing.B) {
+			for i := range b.N {
+				node := nodes[i%len(nodes)]
+				GetHashFromBodyByLine(pkg, node, pkg.Fset.Position(node.Pos()).Line)
+			}
+		})
+
+		b.Run(fmt.Sprintf("cache/%d", count), func(b *testing.B) {
+			for i := range b.N {
+				node := nodes[i%len(nodes)]
+				GetHashFromBodyByLine(cached, node, pkg.Fset.Position(node.Pos()).Line)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// IsTestFile check file is a test file `_test.go`.
+func IsTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.go")
+}
+
+// IsTestVariant check package is compiled for tests, e.g. `p [p.test]` or `p_test [p.test]`.
+func IsTestVariant(pkg *packages.Package) bool {
+	return strings.HasSuffix(pkg.ID, ".test]")
+}
+
+// IsExternalTestPackage check package is an external test package `p_test`.
+func IsExternalTestPackage(pkg *packages.Package) bool {
+	return IsTestVariant(pkg) && strings.HasSuffix(pkg.Name, "_test")
+}
+
+// DeduplicateTestPackages remove packages which are loaded twice with flag `Tests`:
+// package `p` is removed if its test variant `p [p.test]` exists (it contains all files of `p`),
+// generated packages `p.test` with func main for tests are removed too.
+func DeduplicateTestPackages(pkgs []*packages.Package) []*packages.Package {
+	variants := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if IsTestVariant(pkg) && !IsExternalTestPackage(pkg) {
+			variants[pkg.PkgPath] = true
+		}
+	}
+
+	res := make([]*packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+
+		if !IsTestVariant(pkg) && variants[pkg.PkgPath] {
+			continue
+		}
+
+		res = append(res, pkg)
+	}
+
+	return res
+}
+
+// RemoveTestFiles return copies of packages without test files,
+// packages which contain only test files are removed.
+func RemoveTestFiles(pkgs []*packages.Package) []*packages.Package {
+	res := make([]*packages.Package, 0, len(pkgs))
+
+	for _, pkg := range pkgs {
+		if !IsTestVariant(pkg) {
+			res = append(res, pkg)
+			continue
+		}
+
+		clone := *pkg
+		clone.GoFiles = removeTestFilenames(pkg.GoFiles)
+		clone.CompiledGoFiles = removeTestFilenames(pkg.CompiledGoFiles)
+		clone.Syntax = clone.Syntax[:0:0]
+
+		for _, file := range pkg.Syntax {
+			if !IsTestFile(pkg.Fset.Position(file.Pos()).Filename) {
+				clone.Syntax = append(clone.Syntax, file)
+			}
+		}
+
+		if len(clone.GoFiles) == 0 {
+			continue
+		}
+
+		res = append(res, &clone)
+	}
+
+	return res
+}
+
+func removeTestFilenames(filenames []string) []string {
+	res := make([]string, 0, len(filenames))
+	for _, filename := range filenames {
+		if !IsTestFile(filename) {
+			res = append(res, filename)
+		}
+	}
+	return res
+}
@@ -0,0 +1,203 @@
+package analyzers
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+	goanalysis "golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// ConfigPath default path of config file relative to root dir of module.
+const ConfigPath = ".golimiter.yaml"
+
+// GetAll return analyzers for all linters, including linters added by `linters.Register` before the call.
+func GetAll() []*goanalysis.Analyzer {
+	return NewAll(linters.All...)
+}
+
+var configs sync.Map
+
+// GetConfig load config once for each path, it is safe for concurrent use.
+// Required file must exist (e.g. it is passed by flag `-config`), otherwise default config is used without file.
+func GetConfig(path string, required bool) (*config.Config, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(config.GetRootDir(), path)
+	}
+
+	load, _ := configs.LoadOrStore(fmt.Sprintf("%s_%t", path, required), sync.OnceValues(func() (*config.Config, error) {
+		if required {
+			return config.ReadFromFile(path)
+		}
+		return config.ReadFromFileOrDefault(path)
+	}))
+
+	return load.(func() (*config.Config, error))()
+}
+
+// configFlag value of flag `-config`, file is required if flag is set.
+type configFlag struct {
+	path     string
+	required bool
+}
+
+// String return path of config file.
+func (f *configFlag) String() string {
+	return f.path
+}
+
+// Set set path of config file passed by flag.
+func (f *configFlag) Set(path string) error {
+	f.path, f.required = path, true
+	return nil
+}
+
+// NewAll create analyzers for linters.
+func NewAll(linters ...*analysis.Linter) []*goanalysis.Analyzer {
+	analyzers := make([]*goanalysis.Analyzer, 0, len(linters))
+	for _, linter := range linters {
+		analyzers = append(analyzers, New(linter))
+	}
+	return analyzers
+}
+
+// Options of analyzer.
+type Options struct {
+	// Config return config of linters.
+	Config func() (*config.Config, error)
+	// Tests return default value of analyzing test files, linter can override it by `Tests` in config.
+	Tests func() bool
+}
+
+// New create analyzer `golang.org/x/tools/go/analysis` for golimiter linter,
+// so it can be run by `go vet -vettool`, multichecker or gopls.
+// Config is read from file by flag `-config` (file passed by flag must exist, default file is optional),
+// flags are stored per analyzer (e.g. `-NoDefer.config`).
+func New(linter *analysis.Linter) *goanalysis.Analyzer {
+	configPath, tests := &configFlag{path: ConfigPath}, false
+
+	analyzer := NewWithOptions(linter, &Options{
+		Config: func() (*config.Config, error) { return GetConfig(configPath.path, configPath.required) },
+		Tests:  func() bool { return tests },
+	})
+
+	analyzer.Flags.Var(configPath, "config", "path config file (relative to root dir of module)")
+	analyzer.Flags.BoolVar(&tests, "tests", tests, "analyze test files (linter can override it by Tests in config)")
+
+	return analyzer
+}
+
+// NewWithOptions create analyzer for golimiter linter with config from options.
+func NewWithOptions(linter *analysis.Linter, opts *Options) *goanalysis.Analyzer {
+	return &goanalysis.Analyzer{
+		Name: linter.Name,
+		Doc:  linter.Doc,
+		URL:  "https://github.com/mirecl/golimiter",
+		Run: func(pass *goanalysis.Pass) (any, error) {
+			return nil, run(linter, opts, pass)
+		},
+	}
+}
+
+func run(linter *analysis.Linter, opts *Options, pass *goanalysis.Pass) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	tests := opts.Tests()
+	if value := cfg.GetInfo(linter.Name).Tests; value != nil {
+		tests = *value
+	}
+
+	pkg := NewPackage(pass, tests, cfg.IsIncludeGenerated(linter.Name))
+	if len(pkg.Syntax) == 0 {
+		return nil
+	}
+
+	issues, err := linter.Run(cfg, []*analysis.Package{{Package: pkg}})
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		pos := getPos(pkg, issue)
+		if !pos.IsValid() {
+			continue
+		}
+
+		pass.Report(goanalysis.Diagnostic{
+			Pos:      pos,
+			Category: linter.Name,
+			Message:  fmt.Sprintf("%s (%s)", issue.Message, issue.Hash),
+		})
+	}
+
+	return nil
+}
+
+// getPos return position of issue, issue without position (e.g. issue of package) is reported
+// at start of its line in file (name of file and line are not adjusted by `//line` directives).
+func getPos(pkg *packages.Package, issue analysis.Issue) token.Pos {
+	if issue.Pos.IsValid() {
+		return issue.Pos
+	}
+
+	for _, file := range pkg.Syntax {
+		tokenFile := pkg.Fset.File(file.Package)
+		if tokenFile.Name() != issue.Filename || issue.Line < 1 || issue.Line > tokenFile.LineCount() {
+			continue
+		}
+		return tokenFile.LineStart(issue.Line)
+	}
+
+	return token.NoPos
+}
+
+// NewPackage create package go/packages from pass of analyzer,
+// generated files and files of `vendor` are skipped if generated is false.
+func NewPackage(pass *goanalysis.Pass, tests, generated bool) *packages.Package {
+	pkg := &packages.Package{
+		ID:         pass.Pkg.Path(),
+		Name:       pass.Pkg.Name(),
+		PkgPath:    pass.Pkg.Path(),
+		Fset:       pass.Fset,
+		Types:      pass.Pkg,
+		TypesInfo:  pass.TypesInfo,
+		TypesSizes: pass.TypesSizes,
+	}
+
+	for _, file := range pass.Files {
+		// name of file is not adjusted by `//line` directives.
+		filename := pass.Fset.File(file.Package).Name()
+
+		if analysis.IsCgoGeneratedFile(filename) {
+			continue
+		}
+
+		if !generated && analysis.IsGeneratedFile(pass.Fset, file) {
+			continue
+		}
+
+		if analysis.IsTestFile(filename) {
+			// mark package as test variant like go/packages, e.g. `p_test [p.test]`.
+			pkg.ID = fmt.Sprintf("%s [%s.test]", pass.Pkg.Path(), strings.TrimSuffix(pass.Pkg.Path(), "_test"))
+
+			if !tests {
+				continue
+			}
+		}
+
+		pkg.Syntax = append(pkg.Syntax, file)
+		pkg.GoFiles = append(pkg.GoFiles, filename)
+		pkg.CompiledGoFiles = append(pkg.CompiledGoFiles, filename)
+	}
+
+	return pkg
+}
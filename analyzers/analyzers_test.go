@@ -0,0 +1,50 @@
+package analyzers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analyzer := NewWithOptions(linters.NewNoDefer(), &Options{
+		Config: func() (*config.Config, error) { return config.ReadModuleFromBytes(nil) },
+		Tests:  func() bool { return false },
+	})
+
+	results := analysistest.Run(t, analysistest.TestData(), analyzer, "a")
+	require.Len(t, results, 1)
+
+	// diagnostics keep column and position of issue in file with `//line` directive.
+	var columns []int
+	for _, diagnostic := range results[0].Diagnostics {
+		columns = append(columns, results[0].Pass.Fset.PositionFor(diagnostic.Pos, false).Column)
+	}
+	require.Equal(t, []int{2, 2}, columns)
+}
+
+func TestNewFlags(t *testing.T) {
+	noDefer, noInit := New(linters.NewNoDefer()), New(linters.NewNoInit())
+
+	require.NoError(t, noDefer.Flags.Set("config", "custom.yaml"))
+	require.NoError(t, noDefer.Flags.Set("tests", "true"))
+
+	require.Equal(t, "custom.yaml", noDefer.Flags.Lookup("config").Value.String())
+	require.Equal(t, ConfigPath, noInit.Flags.Lookup("config").Value.String())
+	require.Equal(t, "false", noInit.Flags.Lookup("tests").Value.String())
+}
+
+func TestGetConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".golimiter.yaml")
+
+	_, err := GetConfig(path, true)
+	require.Error(t, err)
+
+	cfg, err := GetConfig(path, false)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+}
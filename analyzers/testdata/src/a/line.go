@@ -0,0 +1,6 @@
+package a
+
+func Line() {
+//line template.go:10:5
+	defer println() // want "a `defer` statement forbidden to use"
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+)
+
+// runBaseline run all linters and add hashs of found issues to `ExcludeHashs` of config,
+// so only new issues are reported by next runs.
+func runBaseline(args []string) int {
+	flags := flag.NewFlagSet("golimiter baseline", flag.ExitOnError)
+	beforeFlag := flags.String("before", "", "deadline of excluded hashs, e.g. 2025-12-31 (default without deadline)")
+	load := addLoadFlags(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter baseline [flags] [packages]\n\n")
+		fmt.Fprintf(flags.Output(), "Add hashs of found issues to ExcludeHashs of config file.\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	var before time.Time
+	if *beforeFlag != "" {
+		var err error
+		if before, err = parseBefore(*beforeFlag); err != nil {
+			return exitWithError(err)
+		}
+	}
+
+	cfg, opts, err := load.load(flags.Args())
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// baseline of broken packages is not complete, so config is not changed.
+	allIssues, err := analysis.Run(cfg, opts, linters.All...)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	excludes := make(map[string][]config.ExcludeHash)
+
+	for _, linter := range linters.All {
+		for _, issue := range allIssues[linter.Name] {
+			excludes[linter.Name] = append(excludes[linter.Name], config.ExcludeHash{
+				Hash:    issue.Hash,
+				Before:  before,
+				Comment: fmt.Sprintf("%s:%d %s", analysis.GetPathRelative(issue.Filename), issue.Line, issue.Message),
+			})
+		}
+	}
+
+	count, err := config.AddExcludeHashs(*load.config, excludes)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "golimiter: %d hashs added or renewed in %s\n", count, *load.config)
+
+	return ExitCodeSuccess
+}
+
+// parseBefore parse date `2006-01-02` or time in RFC 3339.
+func parseBefore(value string) (time.Time, error) {
+	if before, err := time.Parse(time.DateOnly, value); err == nil {
+		return before, nil
+	}
+
+	before, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid flag -before, expected date 2006-01-02 or RFC 3339")
+	}
+
+	return before, nil
+}
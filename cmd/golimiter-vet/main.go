@@ -0,0 +1,13 @@
+// golimiter-vet runs golimiter linters as a tool of `go vet`:
+//
+//	go vet -vettool=$(which golimiter-vet) ./...
+package main
+
+import (
+	"github.com/mirecl/golimiter/analyzers"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(analyzers.GetAll()...)
+}
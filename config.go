@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mirecl/golimiter/config"
+)
+
+// schemaFile JSON Schema of config in root of repository, it is regenerated by `golimiter config schema`.
+const schemaFile = "golimiter.schema.json"
+
+// runConfig run subcommand of config, e.g. `golimiter config validate`.
+func runConfig(args []string) int {
+	if len(args) != 0 {
+		switch args[0] {
+		case "validate":
+			return runConfigValidate(args[1:])
+		case "schema":
+			return runConfigSchema(args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Usage: golimiter config validate [flags]\n")
+	fmt.Fprintf(os.Stderr, "       golimiter config schema\n")
+	return ExitCodeError
+}
+
+// runConfigSchema print JSON Schema of config with settings of all linters.
+func runConfigSchema(args []string) int {
+	flags := flag.NewFlagSet("golimiter config schema", flag.ExitOnError)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter config schema\n\n")
+		fmt.Fprintf(flags.Output(), "Print JSON Schema of config (%s is generated by it).\n", schemaFile)
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	schema, err := config.GetSchema()
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	if _, err := os.Stdout.Write(schema); err != nil {
+		return exitWithError(err)
+	}
+
+	return ExitCodeSuccess
+}
+
+// runConfigValidate print all problems of config with position in file.
+// Exit code is ExitCodeIssues if config is invalid.
+func runConfigValidate(args []string) int {
+	flags := flag.NewFlagSet("golimiter config validate", flag.ExitOnError)
+	configFlag := flags.String("config", ".golimiter.yaml", "path config file")
+	dirFlag := flags.String("C", "", "change to dir before running")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter config validate [flags]\n\n")
+		fmt.Fprintf(flags.Output(), "Report unknown linters and keys, invalid values and empty exclusions of config.\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if *dirFlag != "" {
+		if err := os.Chdir(*dirFlag); err != nil {
+			return exitWithError(err)
+		}
+	}
+
+	body, err := os.ReadFile(filepath.Clean(*configFlag))
+	if errors.Is(err, fs.ErrNotExist) {
+		return exitWithError(fmt.Errorf("config file %s does not exist", *configFlag))
+	}
+
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	errs := config.Validate(*configFlag, body)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+
+	if len(errs) != 0 {
+		fmt.Fprintf(os.Stderr, "golimiter: %d problems in config %s\n", len(errs), *configFlag)
+		return ExitCodeIssues
+	}
+
+	return ExitCodeSuccess
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Build configuration for loading packages (build tags, GOOS, GOARCH and cgo).
+type Build struct {
+	Name   string   `yaml:"Name"`
+	Tags   []string `yaml:"Tags"`
+	GOOS   string   `yaml:"GOOS"`
+	GOARCH string   `yaml:"GOARCH"`
+	CGO    *bool    `yaml:"CGO"`
+}
+
+// String return name of build configuration, e.g. `linux/amd64 (integration)`.
+func (b Build) String() string {
+	if b.Name != "" {
+		return b.Name
+	}
+
+	goos := getValueOrEnv(b.GOOS, "GOOS", runtime.GOOS)
+	goarch := getValueOrEnv(b.GOARCH, "GOARCH", runtime.GOARCH)
+
+	name := fmt.Sprintf("%s/%s", goos, goarch)
+	if len(b.Tags) != 0 {
+		name = fmt.Sprintf("%s (%s)", name, strings.Join(b.Tags, ","))
+	}
+
+	if b.CGO != nil && !*b.CGO {
+		name += " nocgo"
+	}
+
+	return name
+}
+
+// GetEnv return environment for go command.
+func (b Build) GetEnv() []string {
+	env := os.Environ()
+
+	if b.GOOS != "" {
+		env = append(env, "GOOS="+b.GOOS)
+	}
+
+	if b.GOARCH != "" {
+		env = append(env, "GOARCH="+b.GOARCH)
+	}
+
+	if b.CGO != nil {
+		if *b.CGO {
+			env = append(env, "CGO_ENABLED=1")
+		} else {
+			env = append(env, "CGO_ENABLED=0")
+		}
+	}
+
+	return env
+}
+
+// GetBuildFlags return build flags for go command.
+func (b Build) GetBuildFlags() []string {
+	if len(b.Tags) == 0 {
+		return nil
+	}
+	return []string{"-tags=" + strings.Join(b.Tags, ",")}
+}
+
+func getValueOrEnv(value, key, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
@@ -1,35 +1,84 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"reflect"
 	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/mod/modfile"
 	"gopkg.in/yaml.v3"
 )
 
+// Config settings of linters for module.
 type Config struct {
-	NoNoLint     NoNoLint      `yaml:"NoNoLint"`
-	NoGoroutine  DefaultLinter `yaml:"NoGoroutine"`
-	NoLength     DefaultLinter `yaml:"NoLength"`
-	NoDefer      DefaultLinter `yaml:"NoDefer"`
-	NoDoc        DefaultLinter `yaml:"NoDoc"`
-	NoInit       DefaultLinter `yaml:"NoInit"`
-	NoGeneric    DefaultLinter `yaml:"NoGeneric"`
-	NoPrefix     DefaultLinter `yaml:"NoPrefix"`
-	NoUnderscore DefaultLinter `yaml:"NoUnderscore"`
-	NoObject     DefaultLinter `yaml:"NoObject"`
-	NoEmbedding  DefaultLinter `yaml:"NoEmbedding"`
+	// Linters settings of registered linters by name (see `Register`).
+	Linters map[string]LinterSettings
+	Builds  []Build
+	// IncludeGenerated analyze generated files and files of `vendor` (from `global`), linter can override it by `Info`.
+	IncludeGenerated bool
+	// infos names of linters with `Info` in section of module.
+	infos map[string]bool
+	// matches keys of exclusions which matched issues in the last run of linters, it is used by audit of exclusions.
+	matches *sync.Map
 }
 
+// Severity of issues in order from the highest to the lowest.
+const (
+	SeverityBlocker  = "BLOCKER"
+	SeverityCritical = "CRITICAL"
+	SeverityMajor    = "MAJOR"
+	SeverityMinor    = "MINOR"
+	SeverityInfo     = "INFO"
+)
+
+// Severities all severity of issues in order from the highest to the lowest.
+var Severities = []string{SeverityBlocker, SeverityCritical, SeverityMajor, SeverityMinor, SeverityInfo}
+
+// IsSeverity check value is known severity.
+func IsSeverity(value string) bool {
+	return slices.Contains(Severities, value)
+}
+
+// IsSeverityAtLeast check severity is equal or higher than threshold.
+// Empty severity is equal to default `BLOCKER`.
+func IsSeverityAtLeast(severity, threshold string) bool {
+	if severity == "" {
+		severity = SeverityBlocker
+	}
+
+	rank := slices.Index(Severities, severity)
+	if rank == -1 {
+		return true
+	}
+
+	return rank <= slices.Index(Severities, threshold)
+}
+
+// Type of issues.
+const (
+	TypeBug           = "BUG"
+	TypeVulnerability = "VULNERABILITY"
+	TypeCodeSmell     = "CODE_SMELL"
+)
+
 type Info struct {
 	Severity string `yaml:"Severity"`
 	Disable  bool   `yaml:"Disable"`
 	Type     string `yaml:"Type"`
+	// EffortMinutes estimated time to fix one issue (used in SonarQube report).
+	EffortMinutes int `yaml:"EffortMinutes"`
+	// Tests analyze test files, if not set flag `-tests` is used.
+	Tests *bool `yaml:"Tests"`
+	// IncludeGenerated analyze generated files and files of `vendor`, if not set `global.IncludeGenerated` is used.
+	IncludeGenerated *bool `yaml:"IncludeGenerated"`
 }
 
 type DefaultLinter struct {
@@ -38,6 +87,8 @@ type DefaultLinter struct {
 	ExcludeFiles   []string      `yaml:"ExcludeFiles"`
 	ExcludeFolders []string      `yaml:"ExcludeFolders"`
 	Info           `yaml:"Info"`
+	// matches exclusions which matched issues, it is shared with config (see `Config.IsUsed`).
+	matches *sync.Map
 }
 
 type NoNoLint struct {
@@ -46,6 +97,114 @@ type NoNoLint struct {
 	ExcludeFiles   []string              `yaml:"ExcludeFiles"`
 	ExcludeFolders []string              `yaml:"ExcludeFolders"`
 	Info           `yaml:"Info"`
+	// matches exclusions which matched issues, it is shared with config (see `Config.IsUsed`).
+	matches *sync.Map
+}
+
+// NoLength settings of linter NoLength with limits of identifiers.
+type NoLength struct {
+	DefaultLinter `yaml:",inline"`
+	// Limits limits of identifiers by kind, zero values are inherited from `Default` and then from defaults of linter.
+	Limits LengthLimits `yaml:"Limits"`
+	// Folders limits for folders, the longest matched path overrides others.
+	Folders []LengthFolder `yaml:"Folders"`
+}
+
+// Kinds of identifiers in limits of linter NoLength.
+const (
+	LengthTypes    = "Types"
+	LengthFuncs    = "Funcs"
+	LengthMethods  = "Methods"
+	LengthFields   = "Fields"
+	LengthParams   = "Params"
+	LengthVars     = "Vars"
+	LengthConsts   = "Consts"
+	LengthPackages = "Packages"
+)
+
+// LengthLimits limits of identifiers by kind, name of field is kind of identifier.
+type LengthLimits struct {
+	Default  LengthLimit `yaml:"Default"`
+	Types    LengthLimit `yaml:"Types"`
+	Funcs    LengthLimit `yaml:"Funcs"`
+	Methods  LengthLimit `yaml:"Methods"`
+	Fields   LengthLimit `yaml:"Fields"`
+	Params   LengthLimit `yaml:"Params"`
+	Vars     LengthLimit `yaml:"Vars"`
+	Consts   LengthLimit `yaml:"Consts"`
+	Packages LengthLimit `yaml:"Packages"`
+}
+
+// LengthLimit maximum length and number of segments of identifier, zero value is not set.
+type LengthLimit struct {
+	MaxLength   int `yaml:"MaxLength"`
+	MaxSegments int `yaml:"MaxSegments"`
+}
+
+// LengthFolder limits of identifiers in folder (path relative to root of module, e.g. `internal/legacy/`).
+type LengthFolder struct {
+	Path   string       `yaml:"Path"`
+	Limits LengthLimits `yaml:"Limits"`
+}
+
+// GetLimit return limit of identifier of kind (e.g. `Funcs`) in file (path relative to root of module),
+// fields without value are taken from limit.
+func (c NoLength) GetLimit(kind, path string, limit LengthLimit) LengthLimit {
+	limit = c.Limits.merge(kind, limit)
+
+	folders := slices.Clone(c.Folders)
+	slices.SortStableFunc(folders, func(a, b LengthFolder) int {
+		return len(a.Path) - len(b.Path)
+	})
+
+	for _, folder := range folders {
+		if IsMatchFolder(folder.Path, path) {
+			limit = folder.Limits.merge(kind, limit)
+		}
+	}
+
+	return limit
+}
+
+// merge override limit by `Default` and limit of kind.
+func (l LengthLimits) merge(kind string, limit LengthLimit) LengthLimit {
+	return l.get(kind).merge(l.Default.merge(limit))
+}
+
+// get return limit of kind, limit of unknown kind is zero.
+func (l LengthLimits) get(kind string) LengthLimit {
+	switch kind {
+	case LengthTypes:
+		return l.Types
+	case LengthFuncs:
+		return l.Funcs
+	case LengthMethods:
+		return l.Methods
+	case LengthFields:
+		return l.Fields
+	case LengthParams:
+		return l.Params
+	case LengthVars:
+		return l.Vars
+	case LengthConsts:
+		return l.Consts
+	case LengthPackages:
+		return l.Packages
+	}
+	return LengthLimit{}
+}
+
+// merge override fields of limit by fields with value.
+func (l LengthLimit) merge(limit LengthLimit) LengthLimit {
+	if l.MaxLength != 0 {
+		limit.MaxLength = l.MaxLength
+	}
+
+	if l.MaxSegments != 0 {
+		limit.MaxSegments = l.MaxSegments
+	}
+
+	return limit
 }
 
 type ExcludeHash struct {
@@ -66,25 +225,47 @@ type ExcludeNameNoNoLint struct {
 	Linters  []string    `yaml:"Linters"`
 }
 
-func (c NoNoLint) IsVerifyHash(hash string) bool {
-	return isVerifyHash(hash, c.ExcludeHashs)
+// IsVerifyHash check issue of linter is excluded by any of hashs (e.g. fingerprint and legacy hash of issue).
+func (c NoNoLint) IsVerifyHash(linter string, hashs ...string) bool {
+	return isVerifyHash(c.matches, linter, hashs, c.ExcludeHashs)
 }
 
-func (c DefaultLinter) IsVerifyHash(hash string) bool {
-	return isVerifyHash(hash, c.ExcludeHashs)
+// IsVerifyHash check issue of linter is excluded by any of hashs (e.g. fingerprint and legacy hash of issue).
+func (c DefaultLinter) IsVerifyHash(linter string, hashs ...string) bool {
+	return isVerifyHash(c.matches, linter, hashs, c.ExcludeHashs)
 }
 
-func isVerifyHash(value string, hashs []ExcludeHash) bool {
-	for _, hash := range hashs {
-		if hash.IsVerify(value) {
-			return true
+// isVerifyHash check any of values is excluded by hashs, matched exclusions are stored in matches of linter.
+func isVerifyHash(matches *sync.Map, linter string, values []string, hashs []ExcludeHash) bool {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		for _, hash := range hashs {
+			if hash.Hash != value {
+				continue
+			}
+
+			storeMatch(matches, getHashMatchKey(linter, hash.Hash))
+
+			if hash.IsVerify(value) {
+				return true
+			}
 		}
 	}
 	return false
 }
 
-func (c DefaultLinter) IsVerifyName(path, name string) bool {
+// IsVerifyName check name of issue of linter in file is excluded by `ExcludeNames`.
+func (c DefaultLinter) IsVerifyName(linter, path, name string) bool {
 	for _, exclude := range c.ExcludeNames {
+		if !exclude.IsMatch(path, name) {
+			continue
+		}
+
+		storeMatch(c.matches, getNameMatchKey(linter, exclude.Path, exclude.Name))
+
 		if exclude.IsVerify(path, name) {
 			return true
 		}
@@ -92,56 +273,46 @@ func (c DefaultLinter) IsVerifyName(path, name string) bool {
 	return false
 }
 
-func (en ExcludeName) IsVerify(path, name string) bool {
-	now := time.Now()
-
-	dir, err := os.Getwd()
-	if err != nil {
-		panic(err)
-	}
-
-	path, err = filepath.Rel(dir, path)
-	if err != nil {
-		panic(err)
-	}
+// IsMatch check name in file is matched by exclusion ignoring `Before`, `Name` and `Path` match exactly
+// or by regular expression with prefix `regex:` (e.g. `regex:New.*` and `regex:internal/.*_gen\.go`).
+func (en ExcludeName) IsMatch(path, name string) bool {
+	path = filepath.ToSlash(GetPathRelative(path))
 
-	if en.Path == path && en.Name == name {
-		if en.Before.IsZero() {
-			return true
-		}
-
-		if now.Before(en.Before) {
-			return true
-		}
-	}
+	return IsMatchPattern(en.Path, path) && IsMatchPattern(en.Name, name)
+}
 
-	return false
+// IsVerify check name in file is excluded and exclusion is not expired.
+func (en ExcludeName) IsVerify(path, name string) bool {
+	return en.IsMatch(path, name) && isActive(en.Before)
 }
 
+// IsVerify check hash is excluded and exclusion is not expired.
 func (eh ExcludeHash) IsVerify(hash string) bool {
-	now := time.Now()
-	if eh.Hash == hash {
-		if eh.Before.IsZero() {
-			return true
-		}
+	return eh.Hash == hash && isActive(eh.Before)
+}
 
-		if now.Before(eh.Before) {
-			return true
-		}
-	}
-	return false
+// isActive check exclusion without `Before` or with `Before` in future.
+func isActive(before time.Time) bool {
+	return before.IsZero() || time.Now().Before(before)
 }
 
-func (c NoNoLint) IsVerifyName(path, name string, linters []string) bool {
+// IsVerifyName check name of function with `nolint` comment for linters in file is excluded by `ExcludeNames`.
+func (c NoNoLint) IsVerifyName(linter, path, name string, linters []string) bool {
 	for _, exclude := range c.ExcludeNames {
 		isVerifyLinter := true
-		for _, linter := range linters {
-			if !slices.Contains(exclude.Linters, linter) {
+		for _, nolint := range linters {
+			if !slices.Contains(exclude.Linters, nolint) {
 				isVerifyLinter = false
 			}
 		}
 
-		if isVerifyLinter && exclude.Position.IsVerify(path, name) {
+		if !isVerifyLinter || !exclude.Position.IsMatch(path, name) {
+			continue
+		}
+
+		storeMatch(c.matches, getNameMatchKey(linter, exclude.Position.Path, exclude.Position.Name))
+
+		if exclude.Position.IsVerify(path, name) {
 			return true
 		}
 	}
@@ -152,6 +323,9 @@ type Global struct {
 	ExcludeFiles   []string         `yaml:"ExcludeFiles"`
 	ExcludeFolders []string         `yaml:"ExcludeFolders"`
 	Linters        map[string]*Info `yaml:"Linters"`
+	Builds         []Build          `yaml:"Builds"`
+	// IncludeGenerated analyze generated files (`// Code generated ... DO NOT EDIT.`) and files of `vendor`.
+	IncludeGenerated bool `yaml:"IncludeGenerated"`
 }
 
 type Settings struct {
@@ -159,28 +333,47 @@ type Settings struct {
 	Module map[string]Config `yaml:"module"`
 }
 
-// ReadFromFile load config file `.golimiter.yaml` or stdin.
+// ReadFromFile load config file `.golimiter.yaml` or stdin, config is validated.
+// It returns error if file does not exist.
 func ReadFromFile(path string) (*Config, error) {
 	var body []byte
 	var err error
 
 	if path == os.Stdin.Name() {
 		body, err = io.ReadAll(os.Stdin)
-		if err != nil {
-			return &Config{}, nil
-		}
 	} else {
 		body, err = os.ReadFile(filepath.Clean(path))
-		if err != nil {
-			return &Config{}, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("config file %s does not exist", path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	return readFromBytes(path, body)
+}
+
+// ReadFromFileOrDefault load config file like `ReadFromFile`,
+// default config is returned if file does not exist (e.g. optional `.golimiter.yaml`).
+func ReadFromFileOrDefault(path string) (*Config, error) {
+	if path != os.Stdin.Name() {
+		if _, err := os.Stat(filepath.Clean(path)); errors.Is(err, fs.ErrNotExist) {
+			return ReadFromBytes(nil)
 		}
 	}
 
-	return ReadFromBytes(body)
+	return ReadFromFile(path)
 }
 
-// ReadFromBytes load config from bytes.
+// ReadFromBytes load config from bytes, config is validated.
 func ReadFromBytes(body []byte) (*Config, error) {
+	return readFromBytes("", body)
+}
+
+func readFromBytes(filename string, body []byte) (*Config, error) {
 	var settings Settings
 
 	gomod, err := ReadModFile()
@@ -188,80 +381,150 @@ func ReadFromBytes(body []byte) (*Config, error) {
 		return nil, err
 	}
 
+	if errs := Validate(filename, body); len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
 	if err = yaml.Unmarshal(body, &settings); err != nil {
 		return nil, err
 	}
 
 	cfg := settings.Module[gomod.Module.Mod.String()]
 
-	// TODO: to be use reflect - mapping config to module
-	if reflect.ValueOf(cfg.NoDefer.Info).IsZero() {
-		cfg.NoDefer.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoDefer")
+	if len(cfg.Builds) == 0 {
+		cfg.Builds = settings.Global.Builds
 	}
-	cfg.NoDefer.ExcludeFiles = append(cfg.NoDefer.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoDefer.ExcludeFolders = append(cfg.NoDefer.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoGeneric.Info).IsZero() {
-		cfg.NoGeneric.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoGeneric")
-	}
-	cfg.NoGeneric.ExcludeFiles = append(cfg.NoGeneric.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoGeneric.ExcludeFolders = append(cfg.NoGeneric.ExcludeFolders, settings.Global.ExcludeFolders...)
+	cfg.IncludeGenerated = settings.Global.IncludeGenerated
+
+	cfg.merge(settings.Global)
+
+	return &cfg, nil
+}
 
-	if reflect.ValueOf(cfg.NoGoroutine.Info).IsZero() {
-		cfg.NoGoroutine.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoGoroutine")
+// ReadModuleFromBytes load config of module from bytes - settings of linters
+// in the same shape as in section `module` without name of module (e.g. settings of golangci-lint plugin).
+// Keys are case-insensitive, linters without `Info` have default severity and type.
+func ReadModuleFromBytes(body []byte) (*Config, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(body, &node); err != nil {
+		return nil, err
 	}
-	cfg.NoGoroutine.ExcludeFiles = append(cfg.NoGoroutine.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoGoroutine.ExcludeFolders = append(cfg.NoGoroutine.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoInit.Info).IsZero() {
-		cfg.NoInit.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoInit")
+	normalizeConfigKeys(&node)
+
+	if errs := validateModule(&node); len(errs) != 0 {
+		return nil, errors.Join(errs...)
 	}
-	cfg.NoInit.ExcludeFiles = append(cfg.NoInit.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoInit.ExcludeFolders = append(cfg.NoInit.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoLength.Info).IsZero() {
-		cfg.NoLength.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoLength")
+	var cfg Config
+	if err := node.Decode(&cfg); err != nil {
+		return nil, err
 	}
-	cfg.NoLength.ExcludeFiles = append(cfg.NoLength.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoLength.ExcludeFolders = append(cfg.NoLength.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoNoLint.Info).IsZero() {
-		cfg.NoNoLint.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoNoLint")
+	cfg.merge(Global{})
+
+	return &cfg, nil
+}
+
+// normalizeConfigKeys replace names of linters and keys of their settings ignoring case.
+func normalizeConfigKeys(node *yaml.Node) {
+	if node.Kind == yaml.DocumentNode {
+		for _, content := range node.Content {
+			normalizeConfigKeys(content)
+		}
+		return
 	}
-	cfg.NoNoLint.ExcludeFiles = append(cfg.NoNoLint.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoNoLint.ExcludeFolders = append(cfg.NoNoLint.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoPrefix.Info).IsZero() {
-		cfg.NoPrefix.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoPrefix")
+	if node.Kind != yaml.MappingNode {
+		return
 	}
-	cfg.NoPrefix.ExcludeFiles = append(cfg.NoPrefix.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoPrefix.ExcludeFolders = append(cfg.NoPrefix.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoUnderscore.Info).IsZero() {
-		cfg.NoUnderscore.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoUnderscore")
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, "Builds") {
+			node.Content[i].Value = "Builds"
+			normalizeKeys(node.Content[i+1], reflect.TypeFor[[]Build]())
+			continue
+		}
+
+		for _, name := range GetLinters() {
+			if strings.EqualFold(name, node.Content[i].Value) {
+				node.Content[i].Value = name
+				normalizeKeys(node.Content[i+1], reflect.TypeOf(NewSettings(name)))
+				break
+			}
+		}
 	}
-	cfg.NoUnderscore.ExcludeFiles = append(cfg.NoUnderscore.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoUnderscore.ExcludeFolders = append(cfg.NoUnderscore.ExcludeFolders, settings.Global.ExcludeFolders...)
+}
 
-	if reflect.ValueOf(cfg.NoObject.Info).IsZero() {
-		cfg.NoObject.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoObject")
+// normalizeKeys replace keys of mapping by names from yaml tags of struct ignoring case,
+// e.g. golangci-lint lowercases keys of settings.
+func normalizeKeys(node *yaml.Node, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
-	cfg.NoObject.ExcludeFiles = append(cfg.NoObject.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoObject.ExcludeFolders = append(cfg.NoObject.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	if reflect.ValueOf(cfg.NoDoc.Info).IsZero() {
-		cfg.NoDoc.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoDoc")
+	switch {
+	case node.Kind == yaml.DocumentNode:
+		for _, content := range node.Content {
+			normalizeKeys(content, t)
+		}
+	case node.Kind == yaml.SequenceNode && t.Kind() == reflect.Slice:
+		for _, content := range node.Content {
+			normalizeKeys(content, t.Elem())
+		}
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Map:
+		for i := 1; i < len(node.Content); i += 2 {
+			normalizeKeys(node.Content[i], t.Elem())
+		}
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Struct:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			for _, field := range getYAMLFields(t) {
+				name := field.Tag.Get("yaml")
+				if name == "" || !strings.EqualFold(name, node.Content[i].Value) {
+					continue
+				}
+
+				node.Content[i].Value = name
+				normalizeKeys(node.Content[i+1], field.Type)
+				break
+			}
+		}
 	}
-	cfg.NoDoc.ExcludeFiles = append(cfg.NoDoc.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoDoc.ExcludeFolders = append(cfg.NoDoc.ExcludeFolders, settings.Global.ExcludeFolders...)
+}
 
-	if reflect.ValueOf(cfg.NoDoc.Info).IsZero() {
-		cfg.NoEmbedding.Info = GetGlobalConfigForLinter(settings.Global.Linters, "NoEmbedding")
+// getYAMLFields return exported fields of struct with fields of inlined structs (e.g. `DefaultLinter` of `NoLength`).
+func getYAMLFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Tag.Get("yaml") == ",inline" {
+			fields = append(fields, getYAMLFields(field.Type)...)
+			continue
+		}
+
+		fields = append(fields, field)
 	}
-	cfg.NoEmbedding.ExcludeFiles = append(cfg.NoEmbedding.ExcludeFiles, settings.Global.ExcludeFiles...)
-	cfg.NoEmbedding.ExcludeFolders = append(cfg.NoEmbedding.ExcludeFolders, settings.Global.ExcludeFolders...)
 
-	return &cfg, nil
+	return fields
+}
+
+// GetInfo return info of linter by name.
+func (c *Config) GetInfo(name string) Info {
+	return *c.Get(name).GetInfo()
+}
+
+// IsIncludeGenerated check linter analyzes generated files and files of `vendor`.
+func (c *Config) IsIncludeGenerated(name string) bool {
+	if include := c.GetInfo(name).IncludeGenerated; include != nil {
+		return *include
+	}
+	return c.IncludeGenerated
 }
 
 func GetGlobalConfigForLinter(global map[string]*Info, name string) Info {
@@ -270,20 +533,86 @@ func GetGlobalConfigForLinter(global map[string]*Info, name string) Info {
 			return *cfg
 		}
 	}
-	return Info{Severity: "BLOCKER", Disable: false, Type: "BUG"}
+	return Info{Severity: SeverityBlocker, Disable: false, Type: TypeBug}
 }
 
-// ReadModFile return info from file go.mod.
+var modFiles sync.Map
+
+// ReadModFile return info from file go.mod of root dir (see GetRootDir).
+// File is read once for each root dir, it is safe for concurrent use.
 func ReadModFile() (*modfile.File, error) {
-	body, err := os.ReadFile("go.mod")
+	filename := filepath.Join(GetRootDir(), "go.mod")
+
+	read, _ := modFiles.LoadOrStore(filename, sync.OnceValues(func() (*modfile.File, error) {
+		body, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		return modfile.Parse(filename, body, nil)
+	}))
+
+	return read.(func() (*modfile.File, error))()
+}
+
+var rootDirs sync.Map
+
+// GetRootDir return root dir of module - the nearest dir with go.mod from working dir
+// (e.g. `go vet` runs tools in dir of package). If go.mod is not found, working dir is returned.
+// Root dir is found once for each working dir, so it follows change of working dir (e.g. by language server).
+func GetRootDir() string {
+	wd, err := os.Getwd()
 	if err != nil {
-		return nil, err
+		return ""
+	}
+
+	if dir, ok := rootDirs.Load(wd); ok {
+		return dir.(string)
 	}
 
-	gomodfile, err := modfile.Parse("go.mod", body, nil)
+	dir, _ := rootDirs.LoadOrStore(wd, findRootDir(wd))
+	return dir.(string)
+}
+
+func findRootDir(wd string) string {
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+
+		if dir == filepath.Dir(dir) {
+			return wd
+		}
+	}
+}
+
+// relativePath key of path relative to root dir.
+type relativePath struct {
+	dir  string
+	path string
+}
+
+var relativePaths sync.Map
+
+// GetPathRelative return path relative to root dir of module.
+// If path can't be made relative, it is returned as is.
+// It is safe for concurrent use.
+func GetPathRelative(path string) string {
+	dir := GetRootDir()
+	if dir == "" || !filepath.IsAbs(path) {
+		return path
+	}
+
+	key := relativePath{dir: dir, path: path}
+	if relPath, ok := relativePaths.Load(key); ok {
+		return relPath.(string)
+	}
+
+	relPath, err := filepath.Rel(dir, path)
 	if err != nil {
-		return nil, err
+		return path
 	}
 
-	return gomodfile, nil
+	relativePaths.Store(key, relPath)
+	return relPath
 }
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoLengthGetLimit(t *testing.T) {
+	settings := NoLength{
+		Limits: LengthLimits{
+			Default: LengthLimit{MaxLength: 30},
+			Funcs:   LengthLimit{MaxSegments: 4},
+		},
+		Folders: []LengthFolder{
+			{Path: "internal/legacy/", Limits: LengthLimits{Default: LengthLimit{MaxLength: 60}, Funcs: LengthLimit{MaxSegments: 6}}},
+			{Path: "internal/", Limits: LengthLimits{Default: LengthLimit{MaxLength: 40}}},
+		},
+	}
+	defaults := LengthLimit{MaxLength: 20, MaxSegments: 3}
+
+	// `Default` overrides defaults of linter, limit of kind overrides `Default`.
+	require.Equal(t, LengthLimit{MaxLength: 30, MaxSegments: 3}, settings.GetLimit(LengthVars, "main.go", defaults))
+	require.Equal(t, LengthLimit{MaxLength: 30, MaxSegments: 4}, settings.GetLimit(LengthFuncs, "main.go", defaults))
+
+	// folder overrides limits of linter, the longest matched folder overrides others.
+	require.Equal(t, LengthLimit{MaxLength: 40, MaxSegments: 4}, settings.GetLimit(LengthFuncs, "internal/app/app.go", defaults))
+	require.Equal(t, LengthLimit{MaxLength: 60, MaxSegments: 6}, settings.GetLimit(LengthFuncs, "internal/legacy/db.go", defaults))
+	require.Equal(t, LengthLimit{MaxLength: 60, MaxSegments: 3}, settings.GetLimit(LengthVars, "internal/legacy/db.go", defaults))
+
+	// unknown kind has limits of `Default`.
+	require.Equal(t, LengthLimit{MaxLength: 30, MaxSegments: 3}, settings.GetLimit("Labels", "main.go", defaults))
+}
+
+func TestGetRootDir(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	// root dir and go.mod follow change of working dir, e.g. by language server.
+	for _, module := range []string{"example.com/app", "example.com/other"} {
+		root, err := filepath.EvalSymlinks(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module "+module+"\n"), 0o600))
+		require.NoError(t, os.Mkdir(filepath.Join(root, "pkg"), 0o750))
+		require.NoError(t, os.Chdir(filepath.Join(root, "pkg")))
+
+		require.Equal(t, root, GetRootDir())
+		require.Equal(t, filepath.Join("pkg", "app.go"), GetPathRelative(filepath.Join(root, "pkg", "app.go")))
+
+		gomod, err := ReadModFile()
+		require.NoError(t, err)
+		require.Equal(t, module, gomod.Module.Mod.Path)
+	}
+}
@@ -0,0 +1,438 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AddExcludeHash append hash to `ExcludeHashs` of linter in section of current module
+// in config file. File is created if not exists, comments of file are kept.
+// Hash which is already excluded is not added twice.
+func AddExcludeHash(path, linter string, exclude ExcludeHash) error {
+	_, err := AddExcludeHashs(path, map[string][]ExcludeHash{linter: {exclude}})
+	return err
+}
+
+// AddExcludeHashs append hashs to `ExcludeHashs` of linters (key is name of linter) in section
+// of current module in config file and return number of added or renewed hashs. File is created if not exists.
+// Entries are inserted into text of file, so comments, indentation and quoting of file are kept
+// (file in flow style is encoded again). Hashs which are already excluded are skipped,
+// expired `Before` of excluded hash is replaced by `Before` of new entry.
+func AddExcludeHashs(path string, excludes map[string][]ExcludeHash) (int, error) {
+	gomod, err := ReadModFile()
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := os.ReadFile(filepath.Clean(path))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return 0, err
+	}
+
+	if editor, ok := newTextEditor(body, &doc); ok && editor.addExcludeHashs(&doc, gomod.Module.Mod.String(), excludes) {
+		if editor.count == 0 {
+			return 0, nil
+		}
+
+		// text is encoded again if edits break yaml, e.g. multi-line value in flow style.
+		var result yaml.Node
+		if body := editor.apply(); yaml.Unmarshal(body, &result) == nil {
+			return editor.count, writeFile(path, body)
+		}
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	module := doc.Content[0]
+	for _, key := range []string{"module", gomod.Module.Mod.String()} {
+		if module, err = getMappingValue(module, key, yaml.MappingNode); err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+
+	for _, linter := range slices.Sorted(maps.Keys(excludes)) {
+		node, err := getMappingValue(module, linter, yaml.MappingNode)
+		if err != nil {
+			return 0, err
+		}
+
+		hashs, err := getMappingValue(node, "ExcludeHashs", yaml.SequenceNode)
+		if err != nil {
+			return 0, err
+		}
+
+		exists := make(map[string]*yaml.Node, len(hashs.Content))
+		for _, item := range hashs.Content {
+			if hash := lookupMappingValue(item, "Hash"); hash != nil {
+				exists[hash.Value] = item
+			}
+		}
+
+		for _, exclude := range excludes[linter] {
+			if item, ok := exists[exclude.Hash]; ok {
+				if updateExpiredHashNode(item, exclude) {
+					count++
+				}
+				continue
+			}
+
+			item := newExcludeHashNode(exclude)
+			exists[exclude.Hash] = item
+
+			hashs.Content = append(hashs.Content, item)
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	return count, writeYAML(path, &doc)
+}
+
+// RemoveExclusions remove exclusions from `ExcludeHashs` and `ExcludeNames` of linters in section
+// of current module in config file and return number of removed entries. Lines of entries are deleted
+// from text of file, so comments, indentation and quoting of file are kept (file in flow style is encoded again).
+func RemoveExclusions(path string, exclusions []Exclusion) (int, error) {
+	gomod, err := ReadModFile()
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return 0, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return 0, err
+	}
+
+	if doc.Kind == 0 {
+		return 0, nil
+	}
+
+	editor, _ := newTextEditor(body, &doc)
+	module := lookupMappingValue(lookupMappingValue(doc.Content[0], "module"), gomod.Module.Mod.String())
+
+	removed := make(map[string]bool, len(exclusions))
+	for _, exclusion := range exclusions {
+		removed[exclusion.Linter+"_"+exclusion.String()] = true
+	}
+
+	count := 0
+
+	for i := 0; module != nil && i+1 < len(module.Content); i += 2 {
+		linter := module.Content[i].Value
+
+		for _, kind := range []string{ExclusionHash, ExclusionName} {
+			key, items := lookupMappingEntry(module.Content[i+1], kind)
+			if items == nil || items.Kind != yaml.SequenceNode || len(items.Content) == 0 {
+				continue
+			}
+
+			var deleted [][2]int
+			end := getEndLine(items)
+
+			kept := items.Content[:0]
+			for _, item := range items.Content {
+				exclusion := Exclusion{Linter: linter, Kind: kind}
+
+				// ExcludeNames of NoNoLint have position of name.
+				position := item
+				if value := lookupMappingValue(item, "Position"); value != nil {
+					position = value
+				}
+
+				if value := lookupMappingValue(item, "Hash"); value != nil {
+					exclusion.Hash = value.Value
+				}
+
+				if value := lookupMappingValue(position, "Name"); value != nil {
+					exclusion.Name = value.Value
+				}
+
+				if value := lookupMappingValue(position, "Path"); value != nil {
+					exclusion.Path = value.Value
+				}
+
+				if removed[linter+"_"+exclusion.String()] {
+					deleted = append(deleted, [2]int{getStartLine(item), getEndLine(item)})
+					count++
+					continue
+				}
+
+				kept = append(kept, item)
+			}
+			items.Content = kept
+
+			// key is deleted with all entries.
+			if len(items.Content) == 0 {
+				deleteMappingKey(module.Content[i+1], kind)
+				deleted = [][2]int{{getStartLine(key), end}}
+			}
+
+			for _, lines := range deleted {
+				if editor != nil {
+					editor.deleteLines(lines[0], lines[1])
+				}
+			}
+		}
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	return count, writeEdited(path, editor, &doc)
+}
+
+// MigrateExcludeHashs replace hashs in `ExcludeHashs` of linters in section of current module in config file
+// by new hashs (key of hashs is name of linter, then old hash) and return number of migrated entries.
+// Entry of old hash with several new hashs is copied for every new hash, `Before` and `Comment` are kept.
+// Hashs are replaced in text of file, so comments, indentation and quoting of file are kept
+// (file in flow style is encoded again).
+func MigrateExcludeHashs(path string, hashs map[string]map[string][]string) (int, error) {
+	gomod, err := ReadModFile()
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return 0, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return 0, err
+	}
+
+	if doc.Kind == 0 {
+		return 0, nil
+	}
+
+	editor, _ := newTextEditor(body, &doc)
+	module := lookupMappingValue(lookupMappingValue(doc.Content[0], "module"), gomod.Module.Mod.String())
+
+	count := 0
+
+	for i := 0; module != nil && i+1 < len(module.Content); i += 2 {
+		linter := module.Content[i].Value
+
+		items := lookupMappingValue(module.Content[i+1], ExclusionHash)
+		if items == nil || items.Kind != yaml.SequenceNode || len(hashs[linter]) == 0 {
+			continue
+		}
+
+		exists := make(map[string]bool, len(items.Content))
+		for _, item := range items.Content {
+			if hash := lookupMappingValue(item, "Hash"); hash != nil {
+				exists[hash.Value] = true
+			}
+		}
+
+		migrated := make([]*yaml.Node, 0, len(items.Content))
+		for _, item := range items.Content {
+			hash := lookupMappingValue(item, "Hash")
+			if hash == nil || len(hashs[linter][hash.Value]) == 0 {
+				migrated = append(migrated, item)
+				continue
+			}
+			count++
+
+			var newHashs []string
+			for _, newHash := range hashs[linter][hash.Value] {
+				if exists[newHash] {
+					continue
+				}
+				exists[newHash] = true
+
+				newHashs = append(newHashs, newHash)
+				migrated = append(migrated, copyExcludeHashNode(item, newHash))
+			}
+
+			if editor != nil {
+				editor.replaceItem(item, "Hash", newHashs)
+			}
+		}
+		items.Content = migrated
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	return count, writeEdited(path, editor, &doc)
+}
+
+// writeEdited write text of file with edits of editor if it is decoded to the same yaml as document,
+// otherwise document is encoded again (e.g. file in flow style or entry with value on several lines).
+func writeEdited(path string, editor *textEditor, doc *yaml.Node) error {
+	if editor != nil {
+		if body := editor.apply(); isEqualYAML(body, doc) {
+			return writeFile(path, body)
+		}
+	}
+
+	return writeYAML(path, doc)
+}
+
+func writeYAML(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return writeFile(path, buf.Bytes())
+}
+
+// writeFile write config file, mode of existing file is kept.
+func writeFile(path string, body []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	return os.WriteFile(filepath.Clean(path), body, mode)
+}
+
+// lookupMappingValue return value of key in mapping node or nil if key not exists.
+func lookupMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// deleteMappingKey delete key and its value from mapping node.
+func deleteMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = slices.Delete(node.Content, i, i+2)
+			return
+		}
+	}
+}
+
+// getMappingValue return value of key in mapping node, value is created if not exists.
+// Empty value (e.g. `NoDefer:` without settings) is converted to node of kind.
+func getMappingValue(node *yaml.Node, key string, kind yaml.Kind) (*yaml.Node, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed add `%s` to config: line %d is not mapping", key, node.Line)
+	}
+
+	value := lookupMappingValue(node, key)
+	if value == nil {
+		value = &yaml.Node{Kind: kind}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+
+	if value.Kind == yaml.ScalarNode && value.Tag == "!!null" && kind != yaml.ScalarNode {
+		*value = yaml.Node{Kind: kind}
+	}
+
+	if value.Kind != kind {
+		return nil, fmt.Errorf("failed add `%s` to config: unexpected value at line %d", key, value.Line)
+	}
+
+	return value, nil
+}
+
+// copyExcludeHashNode return deep copy of entry of `ExcludeHashs` with new hash, comments are kept.
+func copyExcludeHashNode(item *yaml.Node, hash string) *yaml.Node {
+	node := *item
+	node.Content = make([]*yaml.Node, len(item.Content))
+
+	for i, value := range item.Content {
+		copied := *value
+		if i%2 == 1 && item.Content[i-1].Value == "Hash" {
+			copied.Value = hash
+		}
+		node.Content[i] = &copied
+	}
+
+	return &node
+}
+
+// updateExpiredHashNode replace expired `Before` of entry of `ExcludeHashs` by `Before` of exclude
+// (`Before` is deleted if exclude has no deadline) and return true if entry is renewed.
+func updateExpiredHashNode(item *yaml.Node, exclude ExcludeHash) bool {
+	if !isExpiredNode(lookupMappingValue(item, "Before")) {
+		return false
+	}
+
+	if exclude.Before.IsZero() {
+		deleteMappingKey(item, "Before")
+		return true
+	}
+
+	*lookupMappingValue(item, "Before") = yaml.Node{Kind: yaml.ScalarNode, Value: exclude.Before.Format(time.RFC3339)}
+	return true
+}
+
+// isExpiredNode check value of `Before` is passed, empty or invalid value is not expired.
+func isExpiredNode(before *yaml.Node) bool {
+	var value time.Time
+	if before == nil || before.Decode(&value) != nil || value.IsZero() {
+		return false
+	}
+
+	return !isActive(value)
+}
+
+func newExcludeHashNode(exclude ExcludeHash) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	add := func(key, value string) {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+
+	add("Hash", exclude.Hash)
+
+	if !exclude.Before.IsZero() {
+		add("Before", exclude.Before.Format(time.RFC3339))
+	}
+
+	if exclude.Comment != "" {
+		add("Comment", exclude.Comment)
+	}
+
+	return node
+}
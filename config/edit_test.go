@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// copyTestdata copy file of testdata to temp dir with mode and return its path.
+func copyTestdata(t *testing.T, name string, mode os.FileMode) string {
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, body, mode))
+
+	return path
+}
+
+// requireGolden check file is equal to golden file of testdata.
+func requireGolden(t *testing.T, golden, path string) {
+	expected, err := os.ReadFile(filepath.Join("testdata", golden))
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	require.Equal(t, string(expected), string(actual))
+}
+
+func TestAddExcludeHash(t *testing.T) {
+	path := copyTestdata(t, "add_exclude_hash.yaml", 0o600)
+
+	require.NoError(t, AddExcludeHash(path, "NoDefer", ExcludeHash{Hash: "0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1", Comment: "app.go:5"}))
+	require.NoError(t, AddExcludeHash(path, "NoGoroutine", ExcludeHash{Hash: "5a90af01712c1c2960f886090e112ec6"}))
+
+	// hash which is already excluded is not added twice.
+	require.NoError(t, AddExcludeHash(path, "NoDefer", ExcludeHash{Hash: "9272e16ca4af2a3e3910d95cc9ab6411"}))
+
+	requireGolden(t, "add_exclude_hash.golden.yaml", path)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestAddExcludeHashs(t *testing.T) {
+	path := copyTestdata(t, "add_exclude_hashs.yaml", 0o644)
+	before := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	count, err := AddExcludeHashs(path, map[string][]ExcludeHash{
+		"NoDefer": {
+			// expired `Before` is renewed or deleted.
+			{Hash: "9272e16ca4af2a3e3910d95cc9ab6411", Before: before},
+			{Hash: "1ab3e63e5d5e0c5b2cde8f4ddf3e2e6b"},
+			{Hash: "0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1", Comment: "app.go:5: #1"},
+		},
+		"NoInit":      {{Hash: "5a90af01712c1c2960f886090e112ec6"}},
+		"NoGoroutine": {{Hash: "c4ca4238a0b923820dcc509a6f75849b", Before: before}},
+		"NoEmbedding": {{Hash: "c81e728d9d4c2f636f067f89cc14862c"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 6, count)
+
+	requireGolden(t, "add_exclude_hashs.golden.yaml", path)
+
+	// file is not changed if hashs are already excluded.
+	count, err = AddExcludeHashs(path, map[string][]ExcludeHash{"NoInit": {{Hash: "5a90af01712c1c2960f886090e112ec6"}}})
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	requireGolden(t, "add_exclude_hashs.golden.yaml", path)
+}
+
+func TestAddExcludeHashsFlowStyle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".golimiter.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("module: {github.com/mirecl/golimiter: {NoDefer: {ExcludeHashs: [{Hash: a, Before: 2020-01-02}]}}}\n"), 0o600))
+
+	count, err := AddExcludeHashs(path, map[string][]ExcludeHash{"NoDefer": {{Hash: "a"}, {Hash: "b"}}})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	body, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "module: {github.com/mirecl/golimiter: {NoDefer: {ExcludeHashs: [{Hash: a}, {Hash: b}]}}}\n", string(body))
+}
+
+func TestRemoveExclusions(t *testing.T) {
+	path := copyTestdata(t, "remove_exclusions.yaml", 0o644)
+
+	count, err := RemoveExclusions(path, []Exclusion{
+		{Linter: "NoDefer", Kind: ExclusionHash, Hash: "1ab3e63e5d5e0c5b2cde8f4ddf3e2e6b"},
+		{Linter: "NoDefer", Kind: ExclusionName, Name: "regex:New.*", Path: "main.go"},
+		{Linter: "NoNoLint", Kind: ExclusionName, Name: "NewNoInit", Path: "linters/noinit.go"},
+		// exclusion of other linter is not removed.
+		{Linter: "NoDefer", Kind: ExclusionHash, Hash: "5a90af01712c1c2960f886090e112ec6"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	requireGolden(t, "remove_exclusions.golden.yaml", path)
+}
+
+func TestRemoveExclusionsFlowStyle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".golimiter.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("module: {github.com/mirecl/golimiter: {NoDefer: {ExcludeHashs: [{Hash: a}, {Hash: b}]}}}\n"), 0o600))
+
+	count, err := RemoveExclusions(path, []Exclusion{{Linter: "NoDefer", Kind: ExclusionHash, Hash: "a"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	body, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "module: {github.com/mirecl/golimiter: {NoDefer: {ExcludeHashs: [{Hash: b}]}}}\n", string(body))
+}
+
+func TestMigrateExcludeHashs(t *testing.T) {
+	path := copyTestdata(t, "migrate_exclude_hashs.yaml", 0o644)
+
+	count, err := MigrateExcludeHashs(path, map[string]map[string][]string{
+		"NoDefer": {
+			"9272e16ca4af2a3e3910d95cc9ab6411": {"c4ca4238a0b923820dcc509a6f75849b"},
+			// entry is copied for every new hash.
+			"1ab3e63e5d5e0c5b2cde8f4ddf3e2e6b": {"c81e728d9d4c2f636f067f89cc14862c", "eccbc87e4b5ce2fe28308fd9f2a7baf3"},
+			// entry of new hash which is already excluded is removed.
+			"0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1": {"c4ca4238a0b923820dcc509a6f75849b"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	requireGolden(t, "migrate_exclude_hashs.golden.yaml", path)
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Kinds of exclusions in config of linter.
+const (
+	ExclusionHash = "ExcludeHashs"
+	ExclusionName = "ExcludeNames"
+)
+
+// matchRecorder settings of linter which store exclusions matched issues (see `DefaultLinter`).
+type matchRecorder interface {
+	setMatches(matches *sync.Map)
+}
+
+// Exclusion entry of `ExcludeHashs` or `ExcludeNames` of linter in config.
+type Exclusion struct {
+	Linter  string
+	Kind    string
+	Hash    string
+	Name    string
+	Path    string
+	Before  time.Time
+	Comment string
+}
+
+// String return short description of exclusion, e.g. `hash 9921d7...` or `name Foo in path a.go`.
+func (e Exclusion) String() string {
+	if e.Kind == ExclusionHash {
+		return fmt.Sprintf("hash %s", e.Hash)
+	}
+	return fmt.Sprintf("name %s in path %s", e.Name, e.Path)
+}
+
+// IsExpired check `Before` of exclusion is passed at time.
+func (e Exclusion) IsExpired(now time.Time) bool {
+	return !e.Before.IsZero() && !now.Before(e.Before)
+}
+
+// GetExclusions return all exclusions of enabled linters in order of registration of linters.
+func (c *Config) GetExclusions() []Exclusion {
+	var exclusions []Exclusion
+
+	for _, linter := range GetLinters() {
+		settings := c.Get(linter)
+		if settings.GetInfo().Disable {
+			continue
+		}
+
+		hashs, names := settings.GetExclusions()
+
+		for _, hash := range hashs {
+			exclusions = append(exclusions, Exclusion{
+				Linter:  linter,
+				Kind:    ExclusionHash,
+				Hash:    hash.Hash,
+				Before:  hash.Before,
+				Comment: hash.Comment,
+			})
+		}
+
+		for _, name := range names {
+			exclusions = append(exclusions, Exclusion{
+				Linter:  linter,
+				Kind:    ExclusionName,
+				Name:    name.Name,
+				Path:    name.Path,
+				Before:  name.Before,
+				Comment: name.Comment,
+			})
+		}
+	}
+
+	return exclusions
+}
+
+// WithoutExcludeHashs return copy of config without `ExcludeHashs` of linters,
+// so issues excluded by hashs are reported too.
+func (c *Config) WithoutExcludeHashs() *Config {
+	cfg := *c
+	cfg.Linters = make(map[string]LinterSettings, len(c.Linters))
+
+	for name, settings := range c.Linters {
+		settings = cloneSettings(settings)
+		settings.SetExcludeHashs(nil)
+		cfg.Linters[name] = settings
+	}
+
+	return &cfg
+}
+
+// IsUsed check exclusion matched issue of its linter in the last run of linters, even if it is expired.
+func (c *Config) IsUsed(e Exclusion) bool {
+	if c.matches == nil {
+		return false
+	}
+
+	key := getHashMatchKey(e.Linter, e.Hash)
+	if e.Kind == ExclusionName {
+		key = getNameMatchKey(e.Linter, e.Path, e.Name)
+	}
+
+	_, ok := c.matches.Load(key)
+	return ok
+}
+
+// ResetMatches forget exclusions which matched issues, so the next run of linters is audited from scratch.
+func (c *Config) ResetMatches() {
+	if c.matches != nil {
+		c.matches.Clear()
+	}
+}
+
+// storeMatch store key of exclusion which matched issue, matches are not stored without config.
+func storeMatch(matches *sync.Map, key string) {
+	if matches != nil {
+		matches.Store(key, struct{}{})
+	}
+}
+
+func (c *DefaultLinter) setMatches(matches *sync.Map) {
+	c.matches = matches
+}
+
+func (c *NoNoLint) setMatches(matches *sync.Map) {
+	c.matches = matches
+}
+
+func getHashMatchKey(linter, hash string) string {
+	return fmt.Sprintf("%s_hash_%s", linter, hash)
+}
+
+func getNameMatchKey(linter, path, name string) string {
+	return fmt.Sprintf("%s_name_%s_%s", linter, path, name)
+}
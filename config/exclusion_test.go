@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUsed(t *testing.T) {
+	for _, name := range []string{"NoTestA", "NoTestB"} {
+		Register(name, nil)
+		t.Cleanup(func() { Unregister(name) })
+	}
+
+	cfg, err := ReadModuleFromBytes([]byte(`
+NoTestA:
+  ExcludeHashs:
+    - Hash: 9272e16ca4af2a3e3910d95cc9ab6411
+    - Hash: 0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1
+      Before: 2000-01-01
+  ExcludeNames:
+    - Name: New.*
+      Path: main.go
+NoTestB:
+  ExcludeHashs:
+    - Hash: 9272e16ca4af2a3e3910d95cc9ab6411
+`))
+	require.NoError(t, err)
+
+	a := cfg.Get("NoTestA").(*DefaultLinter)
+	require.True(t, a.IsVerifyHash("NoTestA", "9272e16ca4af2a3e3910d95cc9ab6411"))
+	require.False(t, a.IsVerifyHash("NoTestA", "0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1"))
+	require.False(t, a.IsVerifyName("NoTestA", "main.go", "Run"))
+
+	exclusions := cfg.GetExclusions()
+	require.Len(t, exclusions, 4)
+
+	used := make(map[string]bool, len(exclusions))
+	for _, exclusion := range exclusions {
+		used[exclusion.Linter+" "+exclusion.String()] = cfg.IsUsed(exclusion)
+	}
+
+	require.Equal(t, map[string]bool{
+		"NoTestA hash 9272e16ca4af2a3e3910d95cc9ab6411": true,
+		"NoTestA hash 0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1": true,
+		"NoTestA name New.* in path main.go":            false,
+		"NoTestB hash 9272e16ca4af2a3e3910d95cc9ab6411": false,
+	}, used)
+
+	cfg.ResetMatches()
+	for _, exclusion := range exclusions {
+		require.False(t, cfg.IsUsed(exclusion), exclusion.String())
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+import "slices"
+
+// Unregister remove linter from registry, e.g. linter registered by test.
+func Unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	delete(registry, name)
+	registryNames = slices.DeleteFunc(registryNames, func(registered string) bool {
+		return registered == name
+	})
+}
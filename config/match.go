@@ -0,0 +1,129 @@
+package config
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IsExcludedPath check file (path relative to root of module) is matched by glob of files
+// or is in folder matched by glob of folders. Globs support `*`, `?`, `[...]` in segment
+// of path and `**` for any number of segments, e.g. `internal/**/mock_*.go`.
+// Folder matches whole segments (see `IsMatchFolder`), so `api` excludes `api/v1/a.go`, but not `apiclient/a.go`.
+func IsExcludedPath(file string, files, folders []string) bool {
+	file = filepath.ToSlash(file)
+
+	for _, pattern := range files {
+		if IsMatchGlob(pattern, file) {
+			return true
+		}
+	}
+
+	for _, pattern := range folders {
+		if IsMatchFolder(pattern, file) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsMatchFolder check file (path relative to root of module) is in folder matched by glob.
+func IsMatchFolder(pattern, file string) bool {
+	pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+
+	for dir := path.Dir(filepath.ToSlash(file)); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if IsMatchGlob(pattern, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsMatchGlob check path is matched by glob, `**` matches any number of segments (including zero).
+// Empty or invalid glob matches nothing.
+func IsMatchGlob(pattern, name string) bool {
+	if pattern == "" {
+		return false
+	}
+
+	return isMatchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func isMatchSegments(patterns, names []string) bool {
+	for len(patterns) != 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if isMatchSegments(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(names) == 0 {
+			return false
+		}
+
+		if ok, err := path.Match(patterns[0], names[0]); err != nil || !ok {
+			return false
+		}
+
+		patterns, names = patterns[1:], names[1:]
+	}
+
+	return len(names) == 0
+}
+
+// IsValidGlob check glob is valid, e.g. `[` is not closed.
+func IsValidGlob(pattern string) bool {
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// RegexpPrefix prefix of regular expression in `Name` and `Path` of `ExcludeNames`, e.g. `regex:New.*`.
+const RegexpPrefix = "regex:"
+
+// IsMatchPattern check value is equal to pattern or is matched by regular expression after prefix `regex:`,
+// e.g. `main.go` matches only itself and `regex:New.*` matches all constructors.
+func IsMatchPattern(pattern, value string) bool {
+	if expr, ok := strings.CutPrefix(pattern, RegexpPrefix); ok {
+		return IsMatchRegexp(expr, value)
+	}
+	return pattern == value
+}
+
+var regexps sync.Map
+
+// IsMatchRegexp check the whole value is matched by regular expression, e.g. `New.*` matches all constructors.
+// Invalid regexp matches nothing. Compiled regexps are cached, it is safe for concurrent use.
+func IsMatchRegexp(pattern, value string) bool {
+	if re, ok := regexps.Load(pattern); ok {
+		return re.(*regexp.Regexp).MatchString(value)
+	}
+
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false
+	}
+
+	regexps.Store(pattern, re)
+	return re.MatchString(value)
+}
+
+// IsExcludedFile check file is excluded by `ExcludeFiles` or `ExcludeFolders` of linter.
+func (c DefaultLinter) IsExcludedFile(path string) bool {
+	return IsExcludedPath(GetPathRelative(path), c.ExcludeFiles, c.ExcludeFolders)
+}
+
+// IsExcludedFile check file is excluded by `ExcludeFiles` or `ExcludeFolders` of linter.
+func (c NoNoLint) IsExcludedFile(path string) bool {
+	return IsExcludedPath(GetPathRelative(path), c.ExcludeFiles, c.ExcludeFolders)
+}
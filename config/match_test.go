@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsExcludedPath(t *testing.T) {
+	files := []string{"internal/**/mock_*.go", "**/*_gen.go", "main.go"}
+	folders := []string{"api", "pkg/*/legacy/"}
+
+	for path, excluded := range map[string]bool{
+		"internal/mock_db.go":        true,
+		"internal/store/mock_db.go":  true,
+		"internal/store/db.go":       false,
+		"models_gen.go":              true,
+		"api/models_gen.go":          true,
+		"main.go":                    true,
+		"cmd/main.go":                false,
+		"api/v1/handler.go":          true,
+		"apiclient/client.go":        false,
+		"pkg/store/legacy/db.go":     true,
+		"pkg/store/legacy/sql/db.go": true,
+		"pkg/legacy/db.go":           false,
+	} {
+		require.Equal(t, excluded, IsExcludedPath(path, files, folders), path)
+	}
+}
+
+func TestExcludeNameIsVerify(t *testing.T) {
+	exclude := ExcludeName{Name: "regex:New.*", Path: `regex:internal/.*\.go`}
+
+	require.True(t, exclude.IsVerify("internal/app/app.go", "NewApp"))
+	require.False(t, exclude.IsVerify("internal/app/app.go", "RenewApp"))
+	require.False(t, exclude.IsVerify("cmd/main.go", "NewApp"))
+
+	// values without prefix `regex:` match exactly.
+	require.True(t, ExcludeName{Name: "Run", Path: "main.go"}.IsVerify("main.go", "Run"))
+	require.False(t, ExcludeName{Name: "Run", Path: "main.go"}.IsVerify("mainxgo", "Run"))
+	require.True(t, ExcludeName{Name: "Run", Path: "app+v2/(main).go"}.IsVerify("app+v2/(main).go", "Run"))
+	require.False(t, ExcludeName{Name: "regex:(", Path: "main.go"}.IsVerify("main.go", "("))
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// jsonSchema node of JSON Schema (draft-07) of config.
+type jsonSchema struct {
+	Version              string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinLength            int                    `json:"minLength,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Definitions          map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+// patternBefore date or timestamp of `Before`, e.g. `2025-12-31` or `2025-12-31T00:00:00Z`.
+const patternBefore = `^\d{4}-\d{2}-\d{2}([Tt ].+)?$`
+
+// GetSchema return JSON Schema of config `.golimiter.yaml` with settings of registered linters,
+// e.g. for validation and completion by YAML language server.
+func GetSchema() ([]byte, error) {
+	definitions := make(map[string]*jsonSchema)
+
+	linters := getObjectSchema()
+	infos := getObjectSchema()
+	for _, name := range GetLinters() {
+		linters.Properties[name] = getTypeSchema(reflect.TypeOf(NewSettings(name)), definitions)
+		infos.Properties[name] = getTypeSchema(reflect.TypeFor[Info](), definitions)
+	}
+	linters.Properties["Builds"] = getTypeSchema(reflect.TypeFor[[]Build](), definitions)
+
+	global := getStructSchema(reflect.TypeFor[Global](), definitions)
+	global.Properties["Linters"] = infos
+
+	module, err := json.Marshal(linters)
+	if err != nil {
+		return nil, err
+	}
+
+	root := getObjectSchema()
+	root.Version = "http://json-schema.org/draft-07/schema#"
+	root.Title = "golimiter"
+	root.Description = "Config of golimiter (.golimiter.yaml)"
+	root.Definitions = definitions
+	root.Properties["global"] = global
+	root.Properties["module"] = &jsonSchema{
+		Type:                 "object",
+		Description:          "Settings of linters by path of module",
+		AdditionalProperties: module,
+	}
+
+	body, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(body, '\n'), nil
+}
+
+// getObjectSchema return schema of object without additional properties.
+func getObjectSchema() *jsonSchema {
+	return &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: json.RawMessage("false"),
+	}
+}
+
+// getTypeSchema return schema of type decoded from yaml, structs are added to definitions and referenced by name.
+func getTypeSchema(t reflect.Type, definitions map[string]*jsonSchema) *jsonSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		return &jsonSchema{Type: "string", Pattern: patternBefore}
+	case t.Kind() == reflect.Struct:
+		if _, ok := definitions[t.Name()]; !ok {
+			definitions[t.Name()] = getStructSchema(t, definitions)
+		}
+		return &jsonSchema{Ref: "#/definitions/" + t.Name()}
+	case t.Kind() == reflect.Slice:
+		return &jsonSchema{Type: "array", Items: getTypeSchema(t.Elem(), definitions)}
+	case t.Kind() == reflect.Map:
+		items, _ := json.Marshal(getTypeSchema(t.Elem(), definitions))
+		return &jsonSchema{Type: "object", AdditionalProperties: items}
+	case t.Kind() == reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+// getStructSchema return schema of struct by yaml tags of fields,
+// enums and required keys are set like in `Validate`.
+func getStructSchema(t reflect.Type, definitions map[string]*jsonSchema) *jsonSchema {
+	schema := getObjectSchema()
+
+	for _, field := range getYAMLFields(t) {
+		if name := field.Tag.Get("yaml"); name != "" {
+			schema.Properties[name] = getTypeSchema(field.Type, definitions)
+		}
+	}
+
+	switch t {
+	case reflect.TypeFor[Info]():
+		schema.Properties["Severity"].Enum = Severities
+		schema.Properties["Type"].Enum = Types
+	case reflect.TypeFor[ExcludeHash]():
+		schema.Required = []string{"Hash"}
+		schema.Properties["Hash"].MinLength = 1
+	case reflect.TypeFor[ExcludeName]():
+		schema.Required = []string{"Name"}
+		schema.Properties["Name"].MinLength = 1
+	}
+
+	return schema
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LinterSettings settings of linter in section of module, e.g. `*DefaultLinter`.
+// Settings are decoded from yaml, so type of settings is pointer to struct
+// (custom settings can embed `DefaultLinter` with tag `yaml:",inline"`).
+type LinterSettings interface {
+	// GetInfo return info of linter, it is replaced by `global.Linters` if it is not set in module.
+	GetInfo() *Info
+	// GetExclusions return exclusions of issues by hashs and names.
+	GetExclusions() ([]ExcludeHash, []ExcludeName)
+	// SetExcludeHashs replace exclusions of issues by hashs.
+	SetExcludeHashs(hashs []ExcludeHash)
+	// AddExcludePaths add excluded files and folders, e.g. from section `global`.
+	AddExcludePaths(files, folders []string)
+}
+
+// registry factories of default settings of linters by name.
+var (
+	registry      = make(map[string]func() LinterSettings)
+	registryNames []string
+	registryMu    sync.RWMutex
+)
+
+// Register register linter with factory of its default settings, so settings
+// of linter are decoded from config. It panics if linter is registered twice.
+func Register(name string, settings func() LinterSettings) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("config: linter %s is registered twice", name))
+	}
+
+	if settings == nil {
+		settings = NewDefaultLinter
+	}
+
+	registry[name] = settings
+	registryNames = append(registryNames, name)
+}
+
+// GetLinters return names of registered linters in order of registration.
+func GetLinters() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return slices.Clone(registryNames)
+}
+
+// IsRegistered check linter is registered.
+func IsRegistered(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := registry[name]
+	return ok
+}
+
+// NewSettings return default settings of registered linter or `DefaultLinter` if linter is not registered.
+func NewSettings(name string) LinterSettings {
+	registryMu.RLock()
+	settings, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return NewDefaultLinter()
+	}
+
+	return settings()
+}
+
+// NewDefaultLinter return default settings of linter `DefaultLinter`.
+func NewDefaultLinter() LinterSettings {
+	return &DefaultLinter{Info: GetGlobalConfigForLinter(nil, "")}
+}
+
+// NewNoNoLint return default settings of linter `NoNoLint`.
+func NewNoNoLint() LinterSettings {
+	return &NoNoLint{Info: GetGlobalConfigForLinter(nil, "")}
+}
+
+// NewNoLength return default settings of linter `NoLength`.
+func NewNoLength() LinterSettings {
+	return &NoLength{DefaultLinter: DefaultLinter{Info: GetGlobalConfigForLinter(nil, "")}}
+}
+
+// Get return settings of linter, settings of linter without section in config are default.
+func (c *Config) Get(name string) LinterSettings {
+	if settings, ok := c.Linters[name]; ok {
+		return settings
+	}
+	return NewSettings(name)
+}
+
+// UnmarshalYAML decode settings of registered linters by their types, `Builds` and unknown keys.
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: config of module is not mapping", node.Line)
+	}
+
+	c.Linters = make(map[string]LinterSettings)
+	c.infos = make(map[string]bool)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+
+		if key == "Builds" {
+			if err := value.Decode(&c.Builds); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !IsRegistered(key) {
+			continue
+		}
+
+		settings := NewSettings(key)
+		if err := value.Decode(settings); err != nil {
+			return err
+		}
+
+		c.Linters[key] = settings
+		c.infos[key] = lookupMappingValue(value, "Info") != nil
+	}
+
+	return nil
+}
+
+// merge add default settings of registered linters without section in config, set info from `global.Linters`
+// for linters without `Info` in module, add excluded files and folders of `global` and share matches of exclusions.
+func (c *Config) merge(global Global) {
+	if c.Linters == nil {
+		c.Linters = make(map[string]LinterSettings)
+	}
+
+	if c.matches == nil {
+		c.matches = new(sync.Map)
+	}
+
+	for _, name := range GetLinters() {
+		settings, ok := c.Linters[name]
+		if !ok {
+			settings = NewSettings(name)
+			c.Linters[name] = settings
+		}
+
+		if recorder, ok := settings.(matchRecorder); ok {
+			recorder.setMatches(c.matches)
+		}
+
+		if !c.infos[name] {
+			if info, ok := global.Linters[name]; ok && info != nil {
+				*settings.GetInfo() = *info
+			}
+		}
+
+		settings.AddExcludePaths(global.ExcludeFiles, global.ExcludeFolders)
+	}
+}
+
+// cloneSettings return shallow copy of settings.
+func cloneSettings(settings LinterSettings) LinterSettings {
+	value := reflect.ValueOf(settings)
+	if value.Kind() != reflect.Pointer {
+		return settings
+	}
+
+	clone := reflect.New(value.Elem().Type())
+	clone.Elem().Set(value.Elem())
+
+	if cloned, ok := clone.Interface().(LinterSettings); ok {
+		return cloned
+	}
+	return settings
+}
+
+// GetInfo return info of linter.
+func (c *DefaultLinter) GetInfo() *Info {
+	return &c.Info
+}
+
+// GetExclusions return exclusions of issues by hashs and names.
+func (c *DefaultLinter) GetExclusions() ([]ExcludeHash, []ExcludeName) {
+	return c.ExcludeHashs, c.ExcludeNames
+}
+
+// SetExcludeHashs replace exclusions of issues by hashs.
+func (c *DefaultLinter) SetExcludeHashs(hashs []ExcludeHash) {
+	c.ExcludeHashs = hashs
+}
+
+// AddExcludePaths add excluded files and folders.
+func (c *DefaultLinter) AddExcludePaths(files, folders []string) {
+	c.ExcludeFiles = append(c.ExcludeFiles, files...)
+	c.ExcludeFolders = append(c.ExcludeFolders, folders...)
+}
+
+// GetInfo return info of linter.
+func (c *NoNoLint) GetInfo() *Info {
+	return &c.Info
+}
+
+// GetExclusions return exclusions of issues by hashs and positions of names.
+func (c *NoNoLint) GetExclusions() ([]ExcludeHash, []ExcludeName) {
+	names := make([]ExcludeName, 0, len(c.ExcludeNames))
+	for _, name := range c.ExcludeNames {
+		names = append(names, name.Position)
+	}
+	return c.ExcludeHashs, names
+}
+
+// SetExcludeHashs replace exclusions of issues by hashs.
+func (c *NoNoLint) SetExcludeHashs(hashs []ExcludeHash) {
+	c.ExcludeHashs = hashs
+}
+
+// AddExcludePaths add excluded files and folders.
+func (c *NoNoLint) AddExcludePaths(files, folders []string) {
+	c.ExcludeFiles = append(c.ExcludeFiles, files...)
+	c.ExcludeFolders = append(c.ExcludeFolders, folders...)
+}
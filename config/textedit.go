@@ -0,0 +1,371 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// textEdit replace lines [start, end) of file (from 0) by lines, lines are inserted if start equals end.
+type textEdit struct {
+	start int
+	end   int
+	// column of inserted lines, deeper lines are inserted first at the same line.
+	column int
+	lines  []string
+}
+
+// textEditor edit text of config file by positions of yaml nodes, so comments, indentation
+// and quoting of the rest of file are kept.
+type textEditor struct {
+	lines []string
+	// indent indentation of nested mappings.
+	indent int
+	// sequence indentation of items of sequences relative to key.
+	sequence int
+	edits    []textEdit
+	count    int
+}
+
+// newTextEditor create editor of config file in block style, false is returned for empty file
+// or file in flow style (e.g. `{module: {}}`).
+func newTextEditor(body []byte, doc *yaml.Node) (*textEditor, bool) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || !isBlockMapping(doc.Content[0]) {
+		return nil, false
+	}
+
+	e := &textEditor{lines: strings.Split(strings.TrimSuffix(string(body), "\n"), "\n"), indent: 2, sequence: -1}
+	e.detectIndent(doc.Content[0])
+
+	if e.sequence == -1 {
+		e.sequence = e.indent
+	}
+
+	return e, true
+}
+
+// detectIndent set indentation of mappings and sequences by the first nested nodes of file.
+func (e *textEditor) detectIndent(node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		switch {
+		case isBlockMapping(value) && len(value.Content) != 0:
+			e.indent = value.Content[0].Column - key.Column
+			e.detectIndent(value)
+			return
+		case value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) != 0:
+			if e.sequence == -1 {
+				e.sequence = max(getDashColumn(value.Content[0])-(key.Column-1), 0)
+			}
+		}
+	}
+}
+
+// addExcludeHashs add edits of hashs of linters in section of module, false is returned
+// if section can not be edited as text (e.g. it is in flow style).
+func (e *textEditor) addExcludeHashs(doc *yaml.Node, module string, excludes map[string][]ExcludeHash) bool {
+	for _, linter := range slices.Sorted(maps.Keys(excludes)) {
+		if !e.addLinterExcludeHashs(doc.Content[0], []string{"module", module, linter, ExclusionHash}, excludes[linter]) {
+			return false
+		}
+	}
+	return true
+}
+
+// addLinterExcludeHashs add edits of hashs of linter by path of keys, missing keys are added to the last existing mapping.
+func (e *textEditor) addLinterExcludeHashs(node *yaml.Node, keys []string, excludes []ExcludeHash) bool {
+	for i, key := range keys {
+		keyNode, value := lookupMappingEntry(node, key)
+
+		switch {
+		case keyNode == nil:
+			e.insert(getEndLine(node), node.Content[0].Column-1, keys[i:], getNewExcludeHashs(nil, excludes))
+			return true
+		case isEmptyNode(value) && keyNode.Line == value.Line:
+			e.insert(keyNode.Line, keyNode.Column-1+e.indent, keys[i+1:], getNewExcludeHashs(nil, excludes))
+			return true
+		case i == len(keys)-1:
+			return e.addToSequence(value, excludes)
+		case !isBlockMapping(value) || len(value.Content) == 0:
+			return false
+		}
+
+		node = value
+	}
+
+	return true
+}
+
+// addToSequence add edits of new hashs to the end of sequence `ExcludeHashs` and renew expired hashs.
+func (e *textEditor) addToSequence(node *yaml.Node, excludes []ExcludeHash) bool {
+	if node.Kind != yaml.SequenceNode || node.Style&yaml.FlowStyle != 0 || len(node.Content) == 0 {
+		return false
+	}
+
+	column := getDashColumn(node.Content[0])
+	for _, item := range node.Content {
+		if !isBlockMapping(item) || getDashColumn(item) != column || !strings.HasPrefix(e.lines[item.Line-1][column:], "- ") {
+			return false
+		}
+	}
+
+	for _, exclude := range getNewExcludeHashs(nil, excludes) {
+		for _, item := range node.Content {
+			if hash := lookupMappingValue(item, "Hash"); hash != nil && hash.Value == exclude.Hash && !e.updateExpired(item, exclude) {
+				return false
+			}
+		}
+	}
+
+	e.insert(getEndLine(node), column, nil, getNewExcludeHashs(node, excludes))
+	return true
+}
+
+// updateExpired add edit of expired `Before` of entry of `ExcludeHashs`, false is returned if entry can not be edited as text.
+func (e *textEditor) updateExpired(item *yaml.Node, exclude ExcludeHash) bool {
+	key, before := lookupMappingEntry(item, "Before")
+	if !isExpiredNode(before) {
+		return true
+	}
+
+	if key.Line != before.Line || before.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return false
+	}
+
+	e.count++
+
+	if !exclude.Before.IsZero() {
+		line := replaceScalar(e.lines[before.Line-1], before, exclude.Before.Format(time.RFC3339))
+		e.edits = append(e.edits, textEdit{start: before.Line - 1, end: before.Line, lines: []string{line}})
+		return true
+	}
+
+	// the first key of entry is on line of `-`.
+	if key == item.Content[0] {
+		return false
+	}
+
+	e.edits = append(e.edits, textEdit{start: before.Line - 1, end: before.Line})
+	return true
+}
+
+// insert add edit of lines with keys and entries of `ExcludeHashs` after line (from 1), keys start at column.
+func (e *textEditor) insert(line, column int, keys []string, excludes []ExcludeHash) {
+	if len(excludes) == 0 {
+		return
+	}
+
+	var lines []string
+	for i, key := range keys {
+		lines = append(lines, strings.Repeat(" ", column+i*e.indent)+key+":")
+	}
+
+	dash := column
+	if len(keys) != 0 {
+		dash = column + (len(keys)-1)*e.indent + e.sequence
+	}
+
+	for _, exclude := range excludes {
+		lines = append(lines, strings.Repeat(" ", dash)+"- Hash: "+formatScalar(exclude.Hash, 0))
+
+		if !exclude.Before.IsZero() {
+			lines = append(lines, strings.Repeat(" ", dash+2)+"Before: "+exclude.Before.Format(time.RFC3339))
+		}
+
+		if exclude.Comment != "" {
+			lines = append(lines, strings.Repeat(" ", dash+2)+"Comment: "+formatScalar(exclude.Comment, 0))
+		}
+	}
+
+	e.edits = append(e.edits, textEdit{start: line, end: line, column: column, lines: lines})
+	e.count += len(excludes)
+}
+
+// replaceItem add edit which replace lines of entry of sequence by copy of lines for each value of key
+// (e.g. new hashs of `Hash`), entry is deleted if values are empty.
+func (e *textEditor) replaceItem(item *yaml.Node, key string, values []string) {
+	start, end := item.Line, getEndLine(item)
+
+	value := lookupMappingValue(item, key)
+	if value == nil || value.Line < start || value.Line > end {
+		e.deleteLines(start, end)
+		return
+	}
+
+	var lines []string
+	for _, v := range values {
+		copied := slices.Clone(e.lines[start-1 : end])
+		copied[value.Line-start] = replaceScalar(copied[value.Line-start], value, v)
+		lines = append(lines, copied...)
+	}
+
+	e.edits = append(e.edits, textEdit{start: start - 1, end: end, lines: lines})
+}
+
+// deleteLines add edit which delete lines from start to end (from 1, inclusive).
+func (e *textEditor) deleteLines(start, end int) {
+	e.edits = append(e.edits, textEdit{start: start - 1, end: end})
+}
+
+// apply return text of file with edits.
+func (e *textEditor) apply() []byte {
+	slices.SortStableFunc(e.edits, func(a, b textEdit) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		return b.column - a.column
+	})
+
+	lines := make([]string, 0, len(e.lines))
+	line := 0
+
+	for _, edit := range e.edits {
+		// overlapped edits break text, it is checked by `isEqualYAML`.
+		start := min(max(edit.start, line), len(e.lines))
+		lines = append(lines, e.lines[line:start]...)
+		lines = append(lines, edit.lines...)
+		line = min(max(edit.end, start), len(e.lines))
+	}
+
+	lines = append(lines, e.lines[line:]...)
+
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// getNewExcludeHashs return excludes without hashs of sequence and duplicates.
+func getNewExcludeHashs(node *yaml.Node, excludes []ExcludeHash) []ExcludeHash {
+	exists := make(map[string]bool)
+	if node != nil {
+		for _, item := range node.Content {
+			if hash := lookupMappingValue(item, "Hash"); hash != nil {
+				exists[hash.Value] = true
+			}
+		}
+	}
+
+	var result []ExcludeHash
+	for _, exclude := range excludes {
+		if exists[exclude.Hash] {
+			continue
+		}
+		exists[exclude.Hash] = true
+
+		result = append(result, exclude)
+	}
+
+	return result
+}
+
+// lookupMappingEntry return key and value of key in mapping node or nil if key not exists.
+func lookupMappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+// getStartLine return the first line of node (from 1) including lines of head comment, e.g. `# legacy` above entry.
+func getStartLine(node *yaml.Node) int {
+	if node.HeadComment == "" {
+		return node.Line
+	}
+	return max(node.Line-strings.Count(node.HeadComment, "\n")-1, 1)
+}
+
+// getEndLine return the last line of node with children (from 1), lines of block scalars are counted.
+func getEndLine(node *yaml.Node) int {
+	end := node.Line
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		end += strings.Count(strings.TrimSuffix(node.Value, "\n"), "\n") + 1
+	}
+
+	for _, child := range node.Content {
+		end = max(end, getEndLine(child))
+	}
+
+	return end
+}
+
+// getDashColumn return column (from 0) of `-` of item of block sequence.
+func getDashColumn(item *yaml.Node) int {
+	return item.Column - 3
+}
+
+// formatScalar return value as yaml scalar in one line with style (e.g. double quoted), value is quoted if needed.
+func formatScalar(value string, style yaml.Style) string {
+	body, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Value: value, Style: style})
+	if err != nil || strings.Count(string(body), "\n") > 1 {
+		body, _ = yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Value: value, Style: yaml.DoubleQuotedStyle})
+	}
+	return strings.TrimSuffix(string(body), "\n")
+}
+
+// replaceScalar return line with value instead of scalar node in the same style, trailing comment is kept.
+func replaceScalar(line string, node *yaml.Node, value string) string {
+	start := node.Column - 1
+	end := start + len(node.Value)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		end += 2
+	}
+
+	if start < 0 || end > len(line) {
+		return line
+	}
+
+	return line[:start] + formatScalar(value, node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle)) + line[end:]
+}
+
+// isBlockMapping check node is mapping in block style.
+func isBlockMapping(node *yaml.Node) bool {
+	return node.Kind == yaml.MappingNode && node.Style&yaml.FlowStyle == 0
+}
+
+// isEmptyNode check node is empty value, e.g. `NoDefer:` without settings.
+func isEmptyNode(node *yaml.Node) bool {
+	return isNull(node) && node.Value == ""
+}
+
+// isEqualYAML check text is decoded to the same yaml as document, comments, styles and positions are not compared.
+func isEqualYAML(body []byte, doc *yaml.Node) bool {
+	var result yaml.Node
+	if err := yaml.Unmarshal(body, &result); err != nil {
+		return false
+	}
+
+	return isEqualNode(&result, doc)
+}
+
+// isEqualNode check nodes have the same kind, value and children, empty mapping is equal to empty value.
+func isEqualNode(a, b *yaml.Node) bool {
+	if isEmptyValue(a) && isEmptyValue(b) {
+		return true
+	}
+
+	if a.Kind != b.Kind || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+
+	for i := range a.Content {
+		if !isEqualNode(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isEmptyValue check node is empty value or empty mapping, e.g. `NoDefer:` or `NoDefer: {}`.
+func isEmptyValue(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode && len(node.Content) == 0) || isNull(node)
+}
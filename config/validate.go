@@ -0,0 +1,387 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Types types of issues.
+var Types = []string{TypeBug, TypeVulnerability, TypeCodeSmell}
+
+// ValidationError problem of config at position of key or value in yaml.
+type ValidationError struct {
+	Filename string
+	Line     int
+	Column   int
+	Message  string
+}
+
+// Error return problem with position, e.g. `.golimiter.yaml:4:5: unknown linter NoDefr`.
+func (e *ValidationError) Error() string {
+	if e.Filename == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Message)
+}
+
+// validator collect problems of config.
+type validator struct {
+	filename string
+	errs     []*ValidationError
+}
+
+// Validate check config (file is used in position of problems): unknown linters and keys,
+// values of `Severity` and `Type`, timestamps of `Before`, exclusions without `Hash` or `Name`,
+// invalid globs of files and folders and invalid regexps of `ExcludeNames`.
+// All problems are returned as `*ValidationError`, invalid yaml is returned as single error.
+func Validate(filename string, body []byte) []error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(body, &node); err != nil {
+		if filename == "" {
+			return []error{err}
+		}
+		return []error{fmt.Errorf("%s: %w", filename, err)}
+	}
+
+	v := validator{filename: filename}
+	if len(node.Content) != 0 {
+		v.validateRoot(node.Content[0])
+	}
+
+	return v.getErrors()
+}
+
+// validateModule check config of module without name of module (e.g. settings of golangci-lint plugin).
+func validateModule(node *yaml.Node) []error {
+	v := validator{}
+	if node.Kind == yaml.DocumentNode && len(node.Content) != 0 {
+		v.validateLinters(node.Content[0])
+	}
+
+	return v.getErrors()
+}
+
+// getErrors return problems sorted by position.
+func (v *validator) getErrors() []error {
+	slices.SortStableFunc(v.errs, func(a, b *ValidationError) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+
+	errs := make([]error, 0, len(v.errs))
+	for _, err := range v.errs {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// add add problem at position of node.
+func (v *validator) add(node *yaml.Node, message string) {
+	v.errs = append(v.errs, &ValidationError{
+		Filename: v.filename,
+		Line:     node.Line,
+		Column:   node.Column,
+		Message:  message,
+	})
+}
+
+// validateRoot check sections `global` and `module`.
+func (v *validator) validateRoot(node *yaml.Node) {
+	if isNull(node) {
+		return
+	}
+
+	if node.Kind != yaml.MappingNode {
+		v.add(node, "config is not mapping")
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		switch key.Value {
+		case "global":
+			v.validateGlobal(value)
+		case "module":
+			if !v.isMapping(value, "module") {
+				continue
+			}
+
+			for j := 1; j < len(value.Content); j += 2 {
+				v.validateLinters(value.Content[j])
+			}
+		default:
+			v.add(key, fmt.Sprintf("unknown key %s%s", key.Value, getSuggestion(key.Value, []string{"global", "module"})))
+		}
+	}
+}
+
+// validateGlobal check section `global`, keys of `Linters` are names of registered linters.
+func (v *validator) validateGlobal(node *yaml.Node) {
+	if !v.isMapping(node, "global") {
+		return
+	}
+
+	fields := getYAMLFields(reflect.TypeFor[Global]())
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if key.Value != "Linters" {
+			v.validateField(key, value, "global", fields)
+			continue
+		}
+
+		if !v.isMapping(value, "Linters") {
+			continue
+		}
+
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			if v.isLinter(value.Content[j]) {
+				v.validateValue(value.Content[j+1], reflect.TypeFor[Info](), "Info")
+			}
+		}
+	}
+}
+
+// validateLinters check config of module - `Builds` and settings of registered linters.
+func (v *validator) validateLinters(node *yaml.Node) {
+	if !v.isMapping(node, "module") {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if key.Value == "Builds" {
+			v.validateValue(value, reflect.TypeFor[[]Build](), "Builds")
+			continue
+		}
+
+		if v.isLinter(key) {
+			v.validateValue(value, reflect.TypeOf(NewSettings(key.Value)), key.Value)
+		}
+	}
+}
+
+// isLinter check key is name of registered linter.
+func (v *validator) isLinter(key *yaml.Node) bool {
+	if IsRegistered(key.Value) {
+		return true
+	}
+
+	v.add(key, fmt.Sprintf("unknown linter %s%s", key.Value, getSuggestion(key.Value, GetLinters())))
+	return false
+}
+
+// isMapping check node is mapping, empty value is not checked.
+func (v *validator) isMapping(node *yaml.Node, name string) bool {
+	if isNull(node) {
+		return false
+	}
+
+	if node.Kind != yaml.MappingNode {
+		v.add(node, fmt.Sprintf("%s is not mapping", name))
+		return false
+	}
+
+	return true
+}
+
+// validateValue check value by type of field, name is name of key in messages.
+func (v *validator) validateValue(node *yaml.Node, t reflect.Type, name string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if isNull(node) {
+		return
+	}
+
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		var value time.Time
+		if err := node.Decode(&value); err != nil {
+			v.add(node, fmt.Sprintf("invalid timestamp %q of %s, expected date (2006-01-02) or RFC 3339", node.Value, name))
+		}
+	case t.Kind() == reflect.Struct:
+		v.validateStruct(node, t, name)
+	case t.Kind() == reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, fmt.Sprintf("%s is not sequence", name))
+			return
+		}
+
+		for _, content := range node.Content {
+			v.validateValue(content, t.Elem(), name)
+		}
+	case t.Kind() == reflect.Map:
+		if !v.isMapping(node, name) {
+			return
+		}
+
+		for i := 1; i < len(node.Content); i += 2 {
+			v.validateValue(node.Content[i], t.Elem(), name)
+		}
+	default:
+		if err := node.Decode(reflect.New(t).Interface()); err != nil {
+			v.add(node, fmt.Sprintf("invalid value %q of %s, expected %s", node.Value, name, t.Kind()))
+		}
+	}
+}
+
+// validateStruct check keys of struct and values of enums and exclusions.
+func (v *validator) validateStruct(node *yaml.Node, t reflect.Type, name string) {
+	if !v.isMapping(node, name) {
+		return
+	}
+
+	fields := getYAMLFields(t)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		v.validateField(node.Content[i], node.Content[i+1], name, fields)
+	}
+
+	switch t {
+	case reflect.TypeFor[Info]():
+		v.validateEnum(node, "Severity", Severities)
+		v.validateEnum(node, "Type", Types)
+	case reflect.TypeFor[ExcludeHash]():
+		v.validateRequired(node, "Hash", "ExcludeHashs")
+	case reflect.TypeFor[ExcludeName]():
+		v.validateRequired(node, "Name", "ExcludeNames")
+		v.validateRegexp(lookupMappingValue(node, "Name"), "Name")
+		v.validateRegexp(lookupMappingValue(node, "Path"), "Path")
+	case reflect.TypeFor[LengthFolder]():
+		v.validateGlob(lookupMappingValue(node, "Path"), "Path")
+	}
+}
+
+// validateField check key is field of struct and value by type of field.
+func (v *validator) validateField(key, value *yaml.Node, name string, fields []reflect.StructField) {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Tag.Get("yaml"))
+	}
+
+	idx := slices.Index(names, key.Value)
+	if idx == -1 {
+		v.add(key, fmt.Sprintf("unknown key %s in %s%s", key.Value, name, getSuggestion(key.Value, names)))
+		return
+	}
+
+	v.validateValue(value, fields[idx].Type, key.Value)
+
+	if (key.Value == "ExcludeFiles" || key.Value == "ExcludeFolders") && value.Kind == yaml.SequenceNode {
+		for _, content := range value.Content {
+			v.validateGlob(content, key.Value)
+		}
+	}
+}
+
+// validateGlob check value is valid glob of files or folders.
+func (v *validator) validateGlob(node *yaml.Node, name string) {
+	if node != nil && node.Kind == yaml.ScalarNode && !IsValidGlob(node.Value) {
+		v.add(node, fmt.Sprintf("invalid glob %q in %s", node.Value, name))
+	}
+}
+
+// validateRegexp check value with prefix `regex:` is valid regular expression, other values match exactly.
+func (v *validator) validateRegexp(node *yaml.Node, name string) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return
+	}
+
+	expr, ok := strings.CutPrefix(node.Value, RegexpPrefix)
+	if !ok {
+		return
+	}
+
+	if _, err := regexp.Compile(expr); err != nil {
+		v.add(node, fmt.Sprintf("invalid regexp %q of %s: %s", expr, name, err))
+	}
+}
+
+// validateEnum check value of key is one of values, empty value is not checked.
+func (v *validator) validateEnum(node *yaml.Node, key string, values []string) {
+	value := lookupMappingValue(node, key)
+	if value == nil || isNull(value) || value.Value == "" {
+		return
+	}
+
+	if !slices.Contains(values, value.Value) {
+		v.add(value, fmt.Sprintf("invalid %s %s, expected one of %s", key, value.Value, strings.Join(values, ", ")))
+	}
+}
+
+// validateRequired check value of key is not empty.
+func (v *validator) validateRequired(node *yaml.Node, key, name string) {
+	value := lookupMappingValue(node, key)
+	if value == nil || strings.TrimSpace(value.Value) == "" {
+		v.add(node, fmt.Sprintf("empty %s in %s", key, name))
+	}
+}
+
+// isNull check node is empty value, e.g. `NoDefer:` without settings.
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// getSuggestion return hint with the closest name (case-insensitive or with small distance, e.g. typo).
+func getSuggestion(value string, names []string) string {
+	best, distance := "", max(1, len(value)/3)+1
+
+	for _, name := range names {
+		if strings.EqualFold(name, value) {
+			return fmt.Sprintf(" (did you mean %s?)", name)
+		}
+
+		if d := getDistance(strings.ToLower(value), strings.ToLower(name)); d < distance {
+			best, distance = name, d
+		}
+	}
+
+	if best == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" (did you mean %s?)", best)
+}
+
+// getDistance return Levenshtein distance between strings.
+func getDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev = curr
+	}
+
+	return prev[len(b)]
+}
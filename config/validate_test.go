@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	Register("NoTest", nil)
+	t.Cleanup(func() { Unregister("NoTest") })
+
+	errs := Validate("test.yaml", []byte(`
+global:
+  Linters:
+    NoTst:
+      Severity: MAJOR
+module:
+  example.com/app:
+    NoTest:
+      Info:
+        Severity: MAJ
+        Type: BUG
+      ExcludeHashs:
+        - Hash: ""
+          Before: 2025-13-01
+        - Hash: 9272e16ca4af2a3e3910d95cc9ab6411
+          Path: a.go
+      ExcludeFiles:
+        - internal/[a.go
+      ExcludeNames:
+        - Name: regex:New(
+          Path: a.go
+`))
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	require.Equal(t, []string{
+		"test.yaml:4:5: unknown linter NoTst (did you mean NoTest?)",
+		"test.yaml:10:19: invalid Severity MAJ, expected one of BLOCKER, CRITICAL, MAJOR, MINOR, INFO",
+		"test.yaml:13:11: empty Hash in ExcludeHashs",
+		"test.yaml:14:19: invalid timestamp \"2025-13-01\" of Before, expected date (2006-01-02) or RFC 3339",
+		"test.yaml:16:11: unknown key Path in ExcludeHashs",
+		"test.yaml:18:11: invalid glob \"internal/[a.go\" in ExcludeFiles",
+		"test.yaml:20:17: invalid regexp \"New(\" of Name: error parsing regexp: missing closing ): `New(`",
+	}, messages)
+
+	require.Empty(t, Validate("test.yaml", []byte(`
+module:
+  example.com/app:
+    NoTest:
+      ExcludeHashs:
+        - Hash: 9272e16ca4af2a3e3910d95cc9ab6411
+          Before: 2025-12-31
+      ExcludeNames:
+        - Name: Run
+          Path: internal/app+v2/(main).go
+`)))
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchema check JSON Schema of config is regenerated after changes of config types or linters.
+func TestSchema(t *testing.T) {
+	schema, err := config.GetSchema()
+	require.NoError(t, err)
+
+	body, err := os.ReadFile(schemaFile)
+	require.NoError(t, err)
+
+	require.Equal(t, string(schema), string(body), "%s is outdated, run `go run . config schema > %s`", schemaFile, schemaFile)
+}
@@ -0,0 +1,278 @@
+// Package diff parses unified diff (e.g. `git diff` or patch file) and reads git index
+// to report only new issues or issues of staged files.
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Changes lines added by diff, paths are relative to root of diff (slash-separated).
+type Changes struct {
+	// lines added lines by path.
+	lines map[string]map[int]bool
+	// layouts dirs where files are added, deleted, renamed or package clause is changed.
+	layouts map[string]bool
+}
+
+// Parse parse unified diff, lines with prefix `+` in hunks are added lines.
+func Parse(r io.Reader) (*Changes, error) {
+	changes := &Changes{lines: make(map[string]map[int]bool), layouts: make(map[string]bool)}
+
+	var oldPath, newPath string
+	var oldCount, newCount, line int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		// body of hunk, counters protect from lines like `+++` in content.
+		if oldCount > 0 || newCount > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				changes.addLine(newPath, line)
+				if strings.HasPrefix(strings.TrimSpace(text[1:]), "package ") {
+					changes.layouts[path.Dir(newPath)] = true
+				}
+				line++
+				newCount--
+			case strings.HasPrefix(text, "-"):
+				oldCount--
+			case strings.HasPrefix(text, `\`):
+				// `\ No newline at end of file`.
+			default:
+				line++
+				oldCount--
+				newCount--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "diff --git "):
+			oldPath, newPath = "", ""
+		case strings.HasPrefix(text, "rename from "):
+			changes.layouts[path.Dir(strings.TrimPrefix(text, "rename from "))] = true
+		case strings.HasPrefix(text, "rename to "):
+			changes.layouts[path.Dir(strings.TrimPrefix(text, "rename to "))] = true
+		case strings.HasPrefix(text, "--- "):
+			oldPath = trimPrefix(text, "--- ")
+		case strings.HasPrefix(text, "+++ "):
+			newPath = trimPrefix(text, "+++ ")
+
+			// added or deleted file changes layout of package.
+			if oldPath == "/dev/null" {
+				changes.layouts[path.Dir(newPath)] = true
+			}
+
+			if newPath == "/dev/null" {
+				changes.layouts[path.Dir(oldPath)] = true
+			}
+		case strings.HasPrefix(text, "@@ "):
+			match := hunkHeader.FindStringSubmatch(text)
+			if match == nil {
+				return nil, fmt.Errorf("invalid hunk header `%s`", text)
+			}
+
+			oldCount = getCount(match[1])
+			line, _ = strconv.Atoi(match[2])
+			newCount = getCount(match[3])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// FromPatch parse patch file, paths of patch are relative to root of git repository
+// (if module is in subdir of repository) or to root dir of module.
+func FromPatch(filename string) (*Changes, error) {
+	file, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	changes, err := Parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// prefix is empty if module is not in git repository.
+	prefix, _ := git(config.GetRootDir(), "rev-parse", "--show-prefix")
+
+	return changes.relativeTo(strings.TrimSpace(string(prefix))), nil
+}
+
+// FromRev run `git diff` between revision and working tree in root dir of module,
+// untracked files are added as new files.
+func FromRev(rev string) (*Changes, error) {
+	dir := config.GetRootDir()
+
+	out, err := git(dir, "diff", "-U0", "-M", "--no-color", "--no-ext-diff", "--relative", "--src-prefix=a/", "--dst-prefix=b/", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := Parse(bytes.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+
+	out, err = git(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, untracked := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if untracked == "" {
+			continue
+		}
+
+		if err := changes.addFile(filepath.Join(dir, untracked), untracked); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// IsNew check issue is on added line. Issues of whole package (e.g. by NoObject or NoUnderscore)
+// are new only if layout of package is changed.
+func (c *Changes) IsNew(issue analysis.Issue) bool {
+	filename := filepath.ToSlash(analysis.GetPathRelative(issue.Filename))
+
+	if issue.Package {
+		return c.layouts[path.Dir(filename)]
+	}
+
+	return c.lines[filename][issue.Line]
+}
+
+// Filter return only new issues.
+func (c *Changes) Filter(allIssues map[string][]analysis.Issue) map[string][]analysis.Issue {
+	newIssues := make(map[string][]analysis.Issue, len(allIssues))
+
+	for linter, issues := range allIssues {
+		newIssues[linter] = make([]analysis.Issue, 0)
+		for _, issue := range issues {
+			if c.IsNew(issue) {
+				newIssues[linter] = append(newIssues[linter], issue)
+			}
+		}
+	}
+
+	return newIssues
+}
+
+func (c *Changes) addLine(filename string, line int) {
+	if c.lines[filename] == nil {
+		c.lines[filename] = make(map[int]bool)
+	}
+	c.lines[filename][line] = true
+}
+
+// addFile add all lines of new file.
+func (c *Changes) addFile(filename, relPath string) error {
+	body, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+
+	relPath = filepath.ToSlash(relPath)
+	c.layouts[path.Dir(relPath)] = true
+
+	for i := range bytes.Count(body, []byte("\n")) + 1 {
+		c.addLine(relPath, i+1)
+	}
+
+	return nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// relativeTo return changes with paths relative to dir with prefix (e.g. `sub/`), other paths are skipped.
+func (c *Changes) relativeTo(prefix string) *Changes {
+	if prefix == "" {
+		return c
+	}
+
+	changes := &Changes{lines: make(map[string]map[int]bool), layouts: make(map[string]bool)}
+
+	for filename, lines := range c.lines {
+		if relPath, ok := strings.CutPrefix(filename, prefix); ok {
+			changes.lines[relPath] = lines
+		}
+	}
+
+	for dir := range c.layouts {
+		if dir+"/" == prefix {
+			changes.layouts["."] = true
+		} else if relPath, ok := strings.CutPrefix(dir, prefix); ok {
+			changes.layouts[relPath] = true
+		}
+	}
+
+	return changes
+}
+
+// trimPrefix trim prefix of header and prefixes `a/`, `b/` of git.
+func trimPrefix(text, prefix string) string {
+	value := strings.TrimPrefix(text, prefix)
+
+	// timestamp of `diff -u` is separated by tab.
+	value, _, _ = strings.Cut(value, "\t")
+
+	if value == "/dev/null" {
+		return value
+	}
+
+	for _, gitPrefix := range []string{"a/", "b/"} {
+		if trimmed, ok := strings.CutPrefix(value, gitPrefix); ok {
+			return trimmed
+		}
+	}
+
+	return value
+}
+
+func getCount(value string) int {
+	if value == "" {
+		return 1
+	}
+
+	count, _ := strconv.Atoi(value)
+	return count
+}
@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+const patch = `diff --git a/app/app.go b/app/app.go
+index d87ee27..7ab7914 100644
+--- a/app/app.go
++++ b/app/app.go
+@@ -3,2 +3,3 @@ import "fmt"
+ func A() {}
+-func B() {}
++func B() { defer println() }
++++ counter
+@@ -10 +11,0 @@ func C() {}
+-func D() {}
+diff --git a/pkg/new.go b/pkg/new.go
+new file mode 100644
+index 0000000..2fe721c
+--- /dev/null
++++ b/pkg/new.go
+@@ -0,0 +1,2 @@
++package pkg
++
+diff --git a/old/old.go b/old/old.go
+deleted file mode 100644
+--- a/old/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package old
+diff --git a/from/a.go b/to/a.go
+similarity index 100%
+rename from from/a.go
+rename to to/a.go
+`
+
+func TestParse(t *testing.T) {
+	changes, err := Parse(strings.NewReader(patch))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]map[int]bool{
+		"app/app.go": {4: true, 5: true},
+		"pkg/new.go": {1: true, 2: true},
+	}, changes.lines)
+
+	require.Equal(t, map[string]bool{"pkg": true, "old": true, "from": true, "to": true}, changes.layouts)
+}
+
+func TestRelativeTo(t *testing.T) {
+	changes, err := Parse(strings.NewReader(patch))
+	require.NoError(t, err)
+
+	changes = changes.relativeTo("pkg/")
+
+	require.Equal(t, map[string]map[int]bool{"new.go": {1: true, 2: true}}, changes.lines)
+	require.Equal(t, map[string]bool{".": true}, changes.layouts)
+}
+
+func TestIsNew(t *testing.T) {
+	changes, err := Parse(strings.NewReader(patch))
+	require.NoError(t, err)
+
+	require.True(t, changes.IsNew(analysis.Issue{Filename: "app/app.go", Line: 4}))
+	require.False(t, changes.IsNew(analysis.Issue{Filename: "app/app.go", Line: 3}))
+
+	// issue on line 1 is not issue of package.
+	require.True(t, changes.IsNew(analysis.Issue{Filename: "pkg/new.go", Line: 1}))
+	require.False(t, changes.IsNew(analysis.Issue{Filename: "app/app.go", Line: 1}))
+
+	// issue of package is new only if layout of package is changed.
+	require.True(t, changes.IsNew(analysis.Issue{Filename: "to/b.go", Line: 1, Package: true}))
+	require.False(t, changes.IsNew(analysis.Issue{Filename: "app/app.go", Line: 1, Package: true}))
+	require.False(t, changes.IsNew(analysis.Issue{Filename: "app/app.go", Line: 4, Package: true}))
+}
@@ -0,0 +1,145 @@
+package diff
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+// Staged `.go` files of git index.
+type Staged struct {
+	// Files absolute paths of staged files (added, copied, modified or renamed).
+	Files []string
+	// Overlay content from git index of all files of packages with staged files (including files with unstaged
+	// changes and files deleted only in working tree), files which are not in index (e.g. untracked files or files
+	// deleted only in index) are excluded by build constraint, so packages are analyzed as they will be committed.
+	Overlay map[string][]byte
+}
+
+// ignoredFile content of file which is not in git index, build constraint excludes file from package.
+const ignoredFile = "//go:build ignore\n\npackage ignore\n"
+
+// GetStaged read staged `.go` files of module from git index.
+func GetStaged() (*Staged, error) {
+	return getStaged(config.GetRootDir())
+}
+
+func getStaged(dir string) (*Staged, error) {
+	out, err := git(dir, "diff", "--cached", "--name-only", "--relative", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	staged := &Staged{Overlay: make(map[string][]byte)}
+
+	for _, path := range strings.Split(string(out), "\x00") {
+		if filepath.Ext(path) == ".go" {
+			staged.Files = append(staged.Files, filepath.Join(dir, path))
+		}
+	}
+
+	if len(staged.Files) == 0 {
+		return staged, nil
+	}
+
+	if err := staged.readIndex(dir); err != nil {
+		return nil, err
+	}
+
+	return staged, nil
+}
+
+// readIndex set overlay of `.go` files of packages with staged files by `git ls-files --stage`,
+// unmerged files are analyzed from working tree.
+func (s *Staged) readIndex(dir string) error {
+	dirs := s.Dirs()
+
+	args := []string{"ls-files", "--stage", "-z", "--"}
+	for _, pkgDir := range dirs {
+		relDir, err := filepath.Rel(dir, pkgDir)
+		if err != nil {
+			return err
+		}
+		args = append(args, relDir)
+	}
+
+	out, err := git(dir, args...)
+	if err != nil {
+		return err
+	}
+
+	indexed := make(map[string]bool)
+
+	// entry of index is `<mode> <object> <stage>\t<path>`.
+	for _, entry := range strings.Split(string(out), "\x00") {
+		info, path, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		path = filepath.Join(dir, path)
+
+		// files of nested packages are skipped.
+		if !ok || len(fields) != 3 || filepath.Ext(path) != ".go" || !slices.Contains(dirs, filepath.Dir(path)) {
+			continue
+		}
+		indexed[path] = true
+
+		if fields[2] != "0" || !strings.HasPrefix(fields[0], "100") {
+			continue
+		}
+
+		content, err := git(dir, "cat-file", "blob", fields[1])
+		if err != nil {
+			return err
+		}
+
+		s.Overlay[path] = content
+	}
+
+	for _, pkgDir := range dirs {
+		entries, err := os.ReadDir(pkgDir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(pkgDir, entry.Name())
+			if entry.Type().IsRegular() && filepath.Ext(path) == ".go" && !indexed[path] {
+				s.Overlay[path] = []byte(ignoredFile)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Dirs return absolute dirs of packages with staged files.
+func (s *Staged) Dirs() []string {
+	dirs := make([]string, 0, len(s.Files))
+	for _, file := range s.Files {
+		if !slices.Contains(dirs, filepath.Dir(file)) {
+			dirs = append(dirs, filepath.Dir(file))
+		}
+	}
+	return dirs
+}
+
+// Filter return only issues of staged files.
+func (s *Staged) Filter(allIssues map[string][]analysis.Issue) map[string][]analysis.Issue {
+	stagedIssues := make(map[string][]analysis.Issue, len(allIssues))
+
+	for linter, issues := range allIssues {
+		stagedIssues[linter] = make([]analysis.Issue, 0)
+		for _, issue := range issues {
+			if slices.Contains(s.Files, issue.Filename) {
+				stagedIssues[linter] = append(stagedIssues[linter], issue)
+			}
+		}
+	}
+
+	return stagedIssues
+}
@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// writeFiles write files (path relative to dir) with content.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0o600))
+	}
+}
+
+// runGit run git command in dir of repository.
+func runGit(t *testing.T, dir string, args ...string) {
+	_, err := git(dir, append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	require.NoError(t, err)
+}
+
+func TestGetStaged(t *testing.T) {
+	dir := t.TempDir()
+
+	runGit(t, dir, "init", "-q")
+	writeFiles(t, dir, map[string]string{
+		"go.mod":         "module example.com/app\n\ngo 1.23\n",
+		"app/app.go":     "package app\n\nfunc A() {}\n",
+		"app/removed.go": "package app\n\nfunc B() {}\n",
+		"app/cached.go":  "package app\n\nfunc C() {}\n",
+		"app/sub/sub.go": "package sub\n",
+		"other/other.go": "package other\n",
+	})
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+
+	// staged change with unstaged change of the same file.
+	writeFiles(t, dir, map[string]string{"app/app.go": "package app\n\nfunc A() { defer println() }\n"})
+	runGit(t, dir, "add", "app/app.go")
+	writeFiles(t, dir, map[string]string{"app/app.go": "package app\n\nfunc A() { go println() }\n"})
+
+	// file deleted only in working tree, untracked file and file deleted only in index.
+	require.NoError(t, os.Remove(filepath.Join(dir, "app/removed.go")))
+	writeFiles(t, dir, map[string]string{"app/untracked.go": "package app\n\nfunc A() {}\n"})
+	runGit(t, dir, "rm", "-q", "--cached", "app/cached.go")
+
+	staged, err := getStaged(dir)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{filepath.Join(dir, "app/app.go")}, staged.Files)
+	require.Equal(t, map[string][]byte{
+		filepath.Join(dir, "app/app.go"):       []byte("package app\n\nfunc A() { defer println() }\n"),
+		filepath.Join(dir, "app/removed.go"):   []byte("package app\n\nfunc B() {}\n"),
+		filepath.Join(dir, "app/untracked.go"): []byte(ignoredFile),
+		filepath.Join(dir, "app/cached.go"):    []byte(ignoredFile),
+	}, staged.Overlay)
+
+	// package is loaded with files of index.
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedFiles, Dir: dir, Overlay: staged.Overlay}, staged.Dirs()...)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+	require.ElementsMatch(t, []string{filepath.Join(dir, "app/app.go"), filepath.Join(dir, "app/removed.go")}, pkgs[0].GoFiles)
+}
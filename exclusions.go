@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+)
+
+// runExclusions run subcommand of exclusions, e.g. `golimiter exclusions audit`.
+func runExclusions(args []string) int {
+	if len(args) == 0 || args[0] != "audit" {
+		fmt.Fprintf(os.Stderr, "Usage: golimiter exclusions audit [flags] [packages]\n")
+		return ExitCodeError
+	}
+
+	return runExclusionsAudit(args[1:])
+}
+
+// runExclusionsAudit report exclusions which match no issue, expired and expiring exclusions.
+// Exit code is ExitCodeIssues if expired exclusions exist.
+func runExclusionsAudit(args []string) int {
+	flags := flag.NewFlagSet("golimiter exclusions audit", flag.ExitOnError)
+	daysFlag := flags.Int("days", 30, "report exclusions expiring within number of days")
+	pruneFlag := flags.Bool("prune", false, "remove unused exclusions from config file")
+	load := addLoadFlags(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter exclusions audit [flags] [packages]\n\n")
+		fmt.Fprintf(flags.Output(), "Report unused, expired and expiring ExcludeHashs and ExcludeNames.\n")
+		fmt.Fprintf(flags.Output(), "Run it with the same packages and flags as check (e.g. -tests),\n")
+		fmt.Fprintf(flags.Output(), "otherwise exclusions of skipped files are reported as unused.\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	cfg, opts, err := load.load(flags.Args())
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// exclusions of broken packages can't be matched, so audit is not reliable.
+	if _, err := analysis.Run(cfg, opts, linters.All...); err != nil {
+		return exitWithError(err)
+	}
+
+	now := time.Now()
+	soon := now.AddDate(0, 0, *daysFlag)
+
+	var unused []config.Exclusion
+	expired := 0
+
+	for _, exclusion := range cfg.GetExclusions() {
+		var statuses []string
+
+		if !cfg.IsUsed(exclusion) {
+			statuses = append(statuses, "unused")
+			unused = append(unused, exclusion)
+		}
+
+		switch {
+		case exclusion.IsExpired(now):
+			statuses = append(statuses, fmt.Sprintf("expired at %s", exclusion.Before.Format(time.DateOnly)))
+			if cfg.IsUsed(exclusion) || !*pruneFlag {
+				expired++
+			}
+		case exclusion.IsExpired(soon):
+			statuses = append(statuses, fmt.Sprintf("expiring at %s", exclusion.Before.Format(time.DateOnly)))
+		}
+
+		comment := ""
+		if exclusion.Comment != "" {
+			comment = fmt.Sprintf(" (%s)", exclusion.Comment)
+		}
+
+		for _, status := range statuses {
+			fmt.Printf("%s %s: %s is %s%s\n", *load.config, exclusion.Linter, exclusion, status, comment)
+		}
+	}
+
+	if *pruneFlag && len(unused) != 0 {
+		count, err := config.RemoveExclusions(*load.config, unused)
+		if err != nil {
+			return exitWithError(err)
+		}
+
+		fmt.Fprintf(os.Stderr, "golimiter: %d unused exclusions removed from %s\n", count, *load.config)
+	}
+
+	if expired != 0 {
+		return ExitCodeIssues
+	}
+
+	return ExitCodeSuccess
+}
@@ -3,6 +3,7 @@ module github.com/mirecl/golimiter
 go 1.23.0
 
 require (
+	github.com/golangci/plugin-module-register v0.1.2
 	github.com/stretchr/testify v1.8.4
 	golang.org/x/mod v0.25.0
 	golang.org/x/tools v0.34.0
@@ -0,0 +1,80 @@
+// Package golangci is golangci-lint module plugin `golimiter` with all golimiter linters.
+//
+// Settings of plugin have the same shape as config of module in `.golimiter.yaml`:
+//
+//	linters:
+//	  enable:
+//	    - golimiter
+//	  settings:
+//	    custom:
+//	      golimiter:
+//	        type: module
+//	        settings:
+//	          NoLength:
+//	            Info:
+//	              Disable: true
+//	          NoInit:
+//	            ExcludeFiles:
+//	              - cmd/main.go
+//
+// Issues are reported as diagnostics of analyzers, so `//nolint:golimiter`
+// and exclusions of golangci-lint are applied to them.
+package golangci
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"github.com/mirecl/golimiter/analyzers"
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+	goanalysis "golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+// Name of plugin in golangci-lint.
+const Name = "golimiter"
+
+func init() {
+	register.Plugin(Name, New)
+}
+
+// Plugin golangci-lint module plugin.
+type Plugin struct {
+	cfg *config.Config
+}
+
+// New create plugin from settings of golangci-lint.
+func New(settings any) (register.LinterPlugin, error) {
+	body, err := yaml.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.ReadModuleFromBytes(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plugin{cfg: cfg}, nil
+}
+
+// BuildAnalyzers return analyzers for all golimiter linters.
+func (p *Plugin) BuildAnalyzers() ([]*goanalysis.Analyzer, error) {
+	opts := &analyzers.Options{
+		Config: func() (*config.Config, error) { return p.cfg, nil },
+		// test files are loaded by golangci-lint only with `run.tests`,
+		// linter skips them by `Tests` in `Info` of settings (it overrides this default).
+		Tests: func() bool { return true },
+	}
+
+	all := make([]*goanalysis.Analyzer, 0, len(linters.All))
+	for _, linter := range linters.All {
+		all = append(all, analyzers.NewWithOptions(linter, opts))
+	}
+
+	return all, nil
+}
+
+// GetLoadMode return mode of loading packages.
+func (p *Plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
@@ -0,0 +1,36 @@
+package golangci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	goanalysis "golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// getAnalyzer return analyzer of linter built by plugin from settings of golangci-lint.
+func getAnalyzer(t *testing.T, settings map[string]map[string]map[string]bool, name string) *goanalysis.Analyzer {
+	plugin, err := New(settings)
+	require.NoError(t, err)
+
+	all, err := plugin.BuildAnalyzers()
+	require.NoError(t, err)
+
+	for _, analyzer := range all {
+		if analyzer.Name == name {
+			return analyzer
+		}
+	}
+
+	require.Failf(t, "analyzer is not found", name)
+	return nil
+}
+
+func TestPlugin(t *testing.T) {
+	// test files are analyzed by default, golangci-lint loads them only with `run.tests`.
+	analysistest.Run(t, analysistest.TestData(), getAnalyzer(t, nil, "NoDefer"), "tests")
+
+	// keys of settings are lowercased by golangci-lint, `Tests` of linter skips test files.
+	settings := map[string]map[string]map[string]bool{"nodefer": {"info": {"tests": false}}}
+	analysistest.Run(t, analysistest.TestData(), getAnalyzer(t, settings, "NoDefer"), "notests")
+}
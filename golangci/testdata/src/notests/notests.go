@@ -0,0 +1,5 @@
+package notests
+
+func Run() {
+	defer println() // want "a `defer` statement forbidden to use"
+}
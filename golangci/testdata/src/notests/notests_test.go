@@ -0,0 +1,5 @@
+package notests
+
+func runTest() {
+	defer println()
+}
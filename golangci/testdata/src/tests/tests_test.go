@@ -0,0 +1,5 @@
+package tests
+
+func runTest() {
+	defer println() // want "a `defer` statement forbidden to use"
+}
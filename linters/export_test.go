@@ -0,0 +1,17 @@
+package linters
+
+import (
+	"slices"
+
+	"github.com/mirecl/golimiter/analysis"
+)
+
+// Unregister remove linters from `All`, e.g. linters registered by test.
+// Settings of linters are kept in registry of config, so names of test linters must be unique.
+func Unregister(linters ...*analysis.Linter) {
+	for _, linter := range linters {
+		All = slices.DeleteFunc(All, func(registered *analysis.Linter) bool {
+			return registered == linter
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package linters
 
-import "github.com/mirecl/golimiter/analysis"
+import (
+	"fmt"
+	"go/token"
 
-// All lintres for analysis.
-var All = []*analysis.Linter{
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+// All lintres for analysis, additional linters are added by `Register`.
+var All = register(
 	NewNoGeneric(),
 	NewNoInit(),
 	NewNoGoroutine(),
@@ -15,4 +21,82 @@ var All = []*analysis.Linter{
 	NewNoObject(),
 	NewNoDoc(),
 	NewEmbedding(),
+)
+
+// Register add linters to `All` and register their settings in config, so third-party linters
+// are run with golimiter linters and configured in `.golimiter.yaml` by name.
+// Linters must be registered before config is read (e.g. in var declaration of package).
+func Register(linters ...*analysis.Linter) {
+	All = append(All, register(linters...)...)
+}
+
+func register(linters ...*analysis.Linter) []*analysis.Linter {
+	for _, linter := range linters {
+		config.Register(linter.Name, linter.Settings)
+	}
+	return linters
+}
+
+// Check check of package with settings `config.DefaultLinter`.
+type Check func(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error)
+
+// New create linter with settings `config.DefaultLinter`, checks are run for every package
+// if linter is not disabled.
+func New(name, doc string, checks ...Check) *analysis.Linter {
+	return &analysis.Linter{
+		Name:     name,
+		Doc:      doc,
+		Settings: config.NewDefaultLinter,
+		Run: func(cfg *config.Config, pkgs []*analysis.Package) ([]analysis.Issue, error) {
+			settings, err := getSettings(cfg, name)
+			if err != nil {
+				return nil, err
+			}
+
+			issues := make([]analysis.Issue, 0)
+
+			if settings.Disable {
+				return issues, nil
+			}
+
+			for _, pkg := range pkgs {
+				for _, check := range checks {
+					pkgIssues, err := check(settings, pkg)
+					if err != nil {
+						return nil, err
+					}
+					issues = append(issues, pkgIssues...)
+				}
+			}
+
+			return issues, nil
+		},
+	}
+}
+
+// withoutError adapt check of package without errors to `Check`.
+func withoutError(check func(*config.DefaultLinter, *analysis.Package) []analysis.Issue) Check {
+	return func(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
+		return check(cfg, pkg), nil
+	}
+}
+
+// isExcluded check issue at pos is excluded by `ExcludeFiles` and `ExcludeFolders` or by `ExcludeNames`
+// matching name of issue (e.g. name of func, field or variable), issue without name is excluded only by files.
+func isExcluded(cfg *config.DefaultLinter, linter string, pkg *analysis.Package, pos token.Pos, name string) bool {
+	filename := pkg.Fset.Position(pos).Filename
+	if cfg.IsExcludedFile(filename) {
+		return true
+	}
+
+	return name != "" && cfg.IsVerifyName(linter, filename, name)
+}
+
+// getSettings return settings `config.DefaultLinter` of linter.
+func getSettings(cfg *config.Config, name string) (*config.DefaultLinter, error) {
+	settings, ok := cfg.Get(name).(*config.DefaultLinter)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of settings %T of linter %s", cfg.Get(name), name)
+	}
+	return settings, nil
 }
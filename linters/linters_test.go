@@ -0,0 +1,73 @@
+package linters
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// parsePackage return package `example.com/app` with file of source.
+func parsePackage(t *testing.T, src string) *analysis.Package {
+	filename := filepath.Join(t.TempDir(), "app.go")
+	require.NoError(t, os.WriteFile(filename, []byte(src), 0o600))
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	require.NoError(t, err)
+
+	return &analysis.Package{Package: &packages.Package{
+		Name:    file.Name.Name,
+		PkgPath: "example.com/app",
+		Fset:    fset,
+		Syntax:  []*ast.File{file},
+		GoFiles: []string{filename},
+	}}
+}
+
+// getMessages return messages of issues.
+func getMessages(issues []analysis.Issue) []string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
+func TestRegister(t *testing.T) {
+	linter := New("NoTest", "test linter", func(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
+		return []analysis.Issue{{Message: pkg.Name, Severity: cfg.Severity}}, nil
+	})
+	Register(linter)
+	t.Cleanup(func() { Unregister(linter) })
+
+	require.Contains(t, All, linter)
+	require.Panics(t, func() { Register(linter) })
+
+	cfg, err := config.ReadFromBytes([]byte(`
+global:
+  ExcludeFiles:
+    - a.go
+module:
+  github.com/mirecl/golimiter:
+    NoTest:
+      Info:
+        Severity: MINOR
+`))
+	require.NoError(t, err)
+
+	settings, ok := cfg.Get("NoTest").(*config.DefaultLinter)
+	require.True(t, ok)
+	require.Equal(t, []string{"a.go"}, settings.ExcludeFiles)
+
+	issues, err := linter.Run(cfg, []*analysis.Package{{Package: &packages.Package{Name: "app"}}})
+	require.NoError(t, err)
+	require.Equal(t, []analysis.Issue{{Message: "app", Severity: config.SeverityMinor}}, issues)
+}
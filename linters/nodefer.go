@@ -2,42 +2,27 @@ package linters
 
 import (
 	"go/ast"
-	"slices"
-	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoDefer = "NoDefer"
+
 	messageNoDefer = "a `defer` statement forbidden to use"
 )
 
 // NewNoDefer create instance linter for check defer.
 func NewNoDefer() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoDefer",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoDefer.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				pkgIssues := runNoDefer(&cfg.NoDefer, pkg)
-				issues = append(issues, pkgIssues...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoDefer, "forbid usage of `defer` statements", withoutError(runNoDefer))
 }
 
 // TODO: check defer in func with name.
-func runNoDefer(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoDefer(cfg *config.DefaultLinter, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	nodeFilter := []ast.Node{(*ast.DeferStmt)(nil)}
 
 	inspect := inspector.New(pkg.Syntax)
@@ -47,29 +32,25 @@ func runNoDefer(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Iss
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
 		position := pkg.Fset.Position(node.Pos())
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
+		if isExcluded(cfg, nameNoDefer, pkg, node.Pos(), "") {
 			return
 		}
 
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
-		hash := analysis.GetHashFromBody(pkg.Fset, node)
-		if cfg.IsVerifyHash(hash) {
+		hash := occurrences.Get(analysis.GetFingerprint(nameNoDefer, pkg, node))
+		legacy := analysis.GetHashFromBody(pkg, node)
+		if cfg.IsVerifyHash(nameNoDefer, hash, legacy) {
 			return
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  messageNoDefer,
-			Line:     position.Line,
-			Filename: position.Filename,
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    messageNoDefer,
+			Line:       position.Line,
+			Pos:        node.Pos(),
+			Filename:   position.Filename,
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	})
 
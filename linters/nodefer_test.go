@@ -0,0 +1,27 @@
+package linters
+
+import (
+	"testing"
+
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoDeferIdenticalNodes(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+func Run() {
+	defer println()
+	defer println()
+}
+`)
+
+	issues := runNoDefer(&config.DefaultLinter{}, pkg)
+	require.Len(t, issues, 2)
+	require.NotEqual(t, issues[0].Hash, issues[1].Hash)
+	require.Equal(t, issues[0].LegacyHash, issues[1].LegacyHash)
+
+	// the second occurrence is excluded by its own hash.
+	cfg := &config.DefaultLinter{ExcludeHashs: []config.ExcludeHash{{Hash: issues[1].Hash}}}
+	require.Equal(t, issues[:1], runNoDefer(cfg, pkg))
+}
@@ -9,46 +9,33 @@ import (
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoDoc = "NoDoc"
+
 	messageNoDocTag = "in the struct `%s`, the field `%s` does not have a required tag `doc`"
 )
 
 // NewNoDoc create instance linter for check docs.
 func NewNoDoc() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoDoc",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoDoc.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				pkgIssues := runNoDocTag(&cfg.NoDoc, pkg)
-				issues = append(issues, pkgIssues...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoDoc, "require tag `doc` on fields of exported structs in `pkg`", runNoDocTag)
 }
 
-func runNoDocTag(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoDocTag(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
+	occurrences := analysis.Occurrences{}
+
 	var pkgIssues []analysis.Issue
 
 	gomodfile, err := config.ReadModFile()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	pkgName := strings.ReplaceAll(pkg.PkgPath, fmt.Sprintf("%s/", gomodfile.Module.Mod.Path), "")
 
 	if !strings.HasPrefix(pkgName, "pkg") {
-		return pkgIssues
+		return pkgIssues, nil
 	}
 
 	nodeFilter := []ast.Node{(*ast.TypeSpec)(nil)}
@@ -81,25 +68,32 @@ func runNoDocTag(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Is
 			}
 			filedName := field.Names[0].String()
 
+			if isExcluded(cfg, nameNoDoc, pkg, field.Pos(), filedName) {
+				continue
+			}
+
 			if field.Tag != nil && strings.Contains(field.Tag.Value, "doc:") {
 				continue
 			}
 
-			hash := analysis.GetHashFromString(fmt.Sprintf("%s.%s", typeName, filedName))
-			if cfg.IsVerifyHash(hash) {
+			hash := occurrences.Get(analysis.GetFingerprint(nameNoDoc, pkg, field.Names[0]))
+			legacy := analysis.GetHashFromString(fmt.Sprintf("%s.%s", typeName, filedName))
+			if cfg.IsVerifyHash(nameNoDoc, hash, legacy) {
 				continue
 			}
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf(messageNoDocTag, typeName, filedName),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf(messageNoDocTag, typeName, filedName),
+				Line:       position.Line,
+				Pos:        field.Pos(),
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	})
 
-	return pkgIssues
+	return pkgIssues, nil
 }
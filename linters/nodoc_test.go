@@ -0,0 +1,33 @@
+package linters
+
+import (
+	"testing"
+
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoDocExclusions(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+type Config struct {
+	Path string
+	Name string
+}
+`)
+	pkg.PkgPath = "github.com/mirecl/golimiter/pkg/app"
+
+	issues, err := runNoDocTag(&config.DefaultLinter{}, pkg)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	// name excludes only field with this name.
+	cfg := &config.DefaultLinter{ExcludeNames: []config.ExcludeName{{Name: "Path", Path: "regex:.*"}}}
+	issues, err = runNoDocTag(cfg, pkg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"in the struct `Config`, the field `Name` does not have a required tag `doc`"}, getMessages(issues))
+
+	issues, err = runNoDocTag(&config.DefaultLinter{ExcludeFiles: []string{"**/app.go"}}, pkg)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+}
@@ -4,41 +4,27 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"slices"
 	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoEmbedding = "NoEmbedding"
+
 	messageNoStructEmbedding = "a `embedding` struct forbidden to use - field name `%s`"
 )
 
 // NewEmbedding create instance linter for check embedding.
 func NewEmbedding() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoEmbedding",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoEmbedding.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				pkgIssues := runNoStructEmbedding(&cfg.NoEmbedding, pkg)
-				issues = append(issues, pkgIssues...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoEmbedding, "forbid embedded structs without tag `json` in `pkg/request` and `pkg/response`", runNoStructEmbedding)
 }
 
-func runNoStructEmbedding(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoStructEmbedding(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
+	occurrences := analysis.Occurrences{}
+
 	nodeFilter := []ast.Node{(*ast.TypeSpec)(nil)}
 
 	inspect := inspector.New(pkg.Syntax)
@@ -47,13 +33,13 @@ func runNoStructEmbedding(cfg *config.DefaultLinter, pkg *packages.Package) []an
 
 	gomodfile, err := config.ReadModFile()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	pkgName := strings.ReplaceAll(pkg.PkgPath, fmt.Sprintf("%s/", gomodfile.Module.Mod.Path), "")
 
 	if !strings.HasPrefix(pkgName, "pkg/request") && !strings.HasPrefix(pkgName, "pkg/response") {
-		return pkgIssues
+		return pkgIssues, nil
 	}
 
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
@@ -65,24 +51,16 @@ func runNoStructEmbedding(cfg *config.DefaultLinter, pkg *packages.Package) []an
 			return
 		}
 
-		position := pkg.Fset.Position(node.Pos())
-
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
-			return
-		}
-
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
 		for _, field := range findEmbeddedFields(structType) {
 			p := pkg.Fset.Position(field.Pos)
 
-			hash := analysis.GetHashFromString(p.Filename + field.Name + typeSpec.Name.String())
-			if cfg.IsVerifyHash(hash) {
+			if isExcluded(cfg, nameNoEmbedding, pkg, field.Pos, field.Name) {
+				continue
+			}
+
+			hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoEmbedding, pkg, field.Pos, field.Name))
+			legacy := analysis.GetHashFromString(p.Filename + field.Name + typeSpec.Name.String())
+			if cfg.IsVerifyHash(nameNoEmbedding, hash, legacy) {
 				continue
 			}
 
@@ -91,17 +69,19 @@ func runNoStructEmbedding(cfg *config.DefaultLinter, pkg *packages.Package) []an
 			}
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf(messageNoStructEmbedding, field.Name),
-				Line:     p.Line,
-				Filename: p.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf(messageNoStructEmbedding, field.Name),
+				Line:       p.Line,
+				Pos:        field.Pos,
+				Filename:   p.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	})
 
-	return pkgIssues
+	return pkgIssues, nil
 }
 
 // EmbeddedFields info about embedded fields.
@@ -3,42 +3,26 @@ package linters
 import (
 	"go/ast"
 	"go/types"
-	"slices"
-	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoGeneric = "NoGeneric"
+
 	messageNoGeneric = "a `generic` statement forbidden to use"
 )
 
 // NewNoGeneric create instance linter for check generic.
 // Please more info in https://cs.opensource.google/go/x/tools/+/master:go/analysis/passes/usesgenerics/
 func NewNoGeneric() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoGeneric",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoGeneric.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				pkgIssues := runNoGeneric(&cfg.NoGeneric, pkg)
-				issues = append(issues, pkgIssues...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoGeneric, "forbid usage of `generics`", withoutError(runNoGeneric))
 }
 
-func runNoGeneric(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoGeneric(cfg *config.DefaultLinter, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
 
 	nodeFilter := []ast.Node{
 		(*ast.FuncType)(nil),
@@ -54,33 +38,29 @@ func runNoGeneric(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.I
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
 		position := pkg.Fset.Position(node.Pos())
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
+		if isExcluded(cfg, nameNoGeneric, pkg, node.Pos(), "") {
 			return
 		}
 
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
 		if !IsGeneric(node, pkg.TypesInfo) {
 			return
 		}
 
-		hash := analysis.GetHashFromBody(pkg.Fset, node)
-		if cfg.IsVerifyHash(hash) {
+		hash := occurrences.Get(analysis.GetFingerprint(nameNoGeneric, pkg, node))
+		legacy := analysis.GetHashFromBody(pkg, node)
+		if cfg.IsVerifyHash(nameNoGeneric, hash, legacy) {
 			return
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  messageNoGeneric,
-			Line:     position.Line,
-			Filename: position.Filename,
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    messageNoGeneric,
+			Line:       position.Line,
+			Pos:        node.Pos(),
+			Filename:   position.Filename,
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	})
 
@@ -2,43 +2,26 @@ package linters
 
 import (
 	"go/ast"
-	"slices"
-	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoInit = "NoInit"
+
 	messageNoInit = "a `init` funcs forbidden to use"
 )
 
 // NewNoInit create instance linter for check func init.
-//
-//nolint:dupl
 func NewNoInit() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoInit",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoInit.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				pkgIssues := runNoInit(&cfg.NoInit, pkg)
-				issues = append(issues, pkgIssues...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoInit, "set limit for `init` functions", withoutError(runNoInit))
 }
 
-func runNoInit(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoInit(cfg *config.DefaultLinter, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
 
 	inspect := inspector.New(pkg.Syntax)
@@ -48,35 +31,31 @@ func runNoInit(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issu
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
 		position := pkg.Fset.Position(node.Pos())
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
-			return
-		}
-
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
 		fn, _ := node.(*ast.FuncDecl)
 
 		if fn.Name != nil && fn.Name.String() != "init" {
 			return
 		}
 
-		hash := analysis.GetHashFromBody(pkg.Fset, node)
-		if cfg.IsVerifyHash(hash) {
+		if isExcluded(cfg, nameNoInit, pkg, node.Pos(), "init") {
+			return
+		}
+
+		hash := occurrences.Get(analysis.GetFingerprint(nameNoInit, pkg, node))
+		legacy := analysis.GetHashFromBody(pkg, node)
+		if cfg.IsVerifyHash(nameNoInit, hash, legacy) {
 			return
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  messageNoInit,
-			Line:     position.Line,
-			Filename: position.Filename,
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    messageNoInit,
+			Line:       position.Line,
+			Pos:        node.Pos(),
+			Filename:   position.Filename,
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	})
 
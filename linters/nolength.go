@@ -3,21 +3,25 @@ package linters
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 	"slices"
 	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
+// Default limits of identifiers, limits are overridden by `Limits` and `Folders` in config of linter.
 const (
 	MaxLengthObject = 30
 	MaxSegmentCount = 6
 )
 
 const (
+	nameNoLength = "NoLength"
+
 	messageNoLengthLength  = "Maximum allowed length of identifier is"
 	messageNoLengthSegment = "Maximum allowed number of segments in identifier is"
 )
@@ -25,103 +29,231 @@ const (
 // NewNoLength create instance linter for length object.
 func NewNoLength() *analysis.Linter {
 	return &analysis.Linter{
-		Name: "NoLength",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
+		Name:     nameNoLength,
+		Doc:      "limit length and number of segments in identifiers",
+		Settings: config.NewNoLength,
+		Run: func(cfg *config.Config, pkgs []*analysis.Package) ([]analysis.Issue, error) {
+			settings, ok := cfg.Get(nameNoLength).(*config.NoLength)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type of settings %T of linter %s", cfg.Get(nameNoLength), nameNoLength)
+			}
+
 			issues := make([]analysis.Issue, 0)
 
-			if cfg.NoLength.Disable {
-				return issues
+			if settings.Disable {
+				return issues, nil
 			}
 
 			for _, pkg := range pkgs {
-				pkgIssues := runNoLength(&cfg.NoLength, pkg)
+				pkgIssues := runNoLength(settings, pkg)
 				issues = append(issues, pkgIssues...)
 			}
 
-			return issues
+			return issues, nil
 		},
 	}
 }
 
-// TODO: add support ignore hash.
-func runNoLength(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+// lengthIdent identifier checked by NoLength with kind of identifier (name of field of `config.LengthLimits`).
+type lengthIdent struct {
+	ident *ast.Ident
+	kind  string
+}
+
+func runNoLength(cfg *config.NoLength, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	nodeFilter := []ast.Node{
 		(*ast.TypeSpec)(nil),
-		(*ast.Field)(nil),
 		(*ast.FuncDecl)(nil),
+		(*ast.FuncType)(nil),
+		(*ast.StructType)(nil),
+		(*ast.InterfaceType)(nil),
+		(*ast.GenDecl)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.RangeStmt)(nil),
 	}
 
 	inspect := inspector.New(pkg.Syntax)
 
 	var pkgIssues []analysis.Issue
 
-	inspect.Preorder(nodeFilter, func(node ast.Node) {
-		position := pkg.Fset.Position(node.Pos())
+	limits := config.LengthLimit{MaxLength: MaxLengthObject, MaxSegments: MaxSegmentCount}
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
-			return
+	// newIssues return issues of length and number of segments of name.
+	newIssues := func(name, kind string, position token.Position, hash, legacy string) []analysis.Issue {
+		var issues []analysis.Issue
+
+		limit := cfg.GetLimit(kind, analysis.GetPathRelative(position.Filename), limits)
+
+		if len(name) > limit.MaxLength {
+			issues = append(issues, analysis.Issue{
+				Message:    fmt.Sprintf("%s %d (now %d)", messageNoLengthLength, limit.MaxLength, len(name)),
+				Line:       position.Line,
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
+			})
 		}
 
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
+		segment := GetSegmentCount(name)
+		if segment > limit.MaxSegments {
+			issues = append(issues, analysis.Issue{
+				Message:    fmt.Sprintf("%s %d (now %d)", messageNoLengthSegment, limit.MaxSegments, segment),
+				Line:       position.Line,
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
+			})
+		}
+
+		return issues
+	}
+
+	check := func(item lengthIdent) {
+		if item.ident == nil || item.ident.Name == "_" {
+			return
 		}
 
-		name := GetObjectName(node)
-		if name == "" {
+		if isExcluded(&cfg.DefaultLinter, nameNoLength, pkg, item.ident.Pos(), item.ident.Name) {
 			return
 		}
 
-		hash := analysis.GetHashFromString(name)
-		if cfg.IsVerifyHash(hash) {
+		hash := occurrences.Get(analysis.GetFingerprint(nameNoLength, pkg, item.ident))
+		legacy := analysis.GetHashFromString(item.ident.Name)
+		if cfg.IsVerifyHash(nameNoLength, hash, legacy) {
 			return
 		}
 
-		if len(name) > MaxLengthObject {
-			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf("%s %d (now %d)", messageNoLengthLength, MaxLengthObject, len(name)),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
-			})
+		for _, issue := range newIssues(item.ident.Name, item.kind, pkg.Fset.Position(item.ident.Pos()), hash, legacy) {
+			issue.Pos = item.ident.Pos()
+			pkgIssues = append(pkgIssues, issue)
 		}
+	}
 
-		segment := GetSegmentCount(name)
-		if segment > MaxSegmentCount {
-			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf("%s %d (now %d)", messageNoLengthSegment, MaxSegmentCount, segment),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     analysis.GetHashFromString(name),
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
-			})
+	// name of package is checked once in the first not excluded file by name,
+	// so issue and its hash don't depend on order of files.
+	if filename := getPackageFile(&cfg.DefaultLinter, pkg); filename != "" {
+		name := pkg.Name
+
+		// suffix `_test` is required for external test packages.
+		if analysis.IsExternalTestPackage(pkg.Package) {
+			name = strings.TrimSuffix(name, "_test")
+		}
+
+		hash := analysis.GetFingerprintByPos(nameNoLength, pkg, token.NoPos, name)
+		legacy := analysis.GetHashFromString(name)
+		if !cfg.IsVerifyHash(nameNoLength, hash, legacy) && !cfg.IsVerifyName(nameNoLength, filename, pkg.Name) {
+			for _, issue := range newIssues(name, config.LengthPackages, token.Position{Filename: filename, Line: 1}, hash, legacy) {
+				issue.Package = true
+				pkgIssues = append(pkgIssues, issue)
+			}
+		}
+	}
+
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		for _, item := range getLengthIdents(node, pkg.TypesInfo) {
+			check(item)
 		}
 	})
+
 	return pkgIssues
 }
 
-func GetObjectName(node ast.Node) string {
+// getPackageFile return the first file of package by name which is not excluded by config.
+func getPackageFile(cfg *config.DefaultLinter, pkg *analysis.Package) string {
+	var filenames []string
+	for _, file := range pkg.Syntax {
+		filenames = append(filenames, pkg.Fset.File(file.Package).Name())
+	}
+	slices.Sort(filenames)
+
+	for _, filename := range filenames {
+		if !cfg.IsExcludedFile(filename) {
+			return filename
+		}
+	}
+
+	return ""
+}
+
+// getLengthIdents return declared identifiers of node with kind, e.g. names of params of func type.
+func getLengthIdents(node ast.Node, info *types.Info) []lengthIdent {
+	var idents []lengthIdent
+
+	addFields := func(fields *ast.FieldList, kind string) {
+		if fields == nil {
+			return
+		}
+
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				idents = append(idents, lengthIdent{ident: name, kind: kind})
+			}
+		}
+	}
+
+	addExprs := func(exprs ...ast.Expr) {
+		for _, expr := range exprs {
+			ident, ok := expr.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			// `:=` declares only new variables, others are used.
+			if info != nil && info.Uses[ident] != nil {
+				continue
+			}
+
+			idents = append(idents, lengthIdent{ident: ident, kind: config.LengthVars})
+		}
+	}
+
 	switch n := node.(type) {
 	case *ast.TypeSpec:
-		if n.Name == nil {
-			return ""
+		idents = append(idents, lengthIdent{ident: n.Name, kind: config.LengthTypes})
+		addFields(n.TypeParams, config.LengthParams)
+	case *ast.FuncDecl:
+		if n.Recv == nil {
+			idents = append(idents, lengthIdent{ident: n.Name, kind: config.LengthFuncs})
+		} else {
+			idents = append(idents, lengthIdent{ident: n.Name, kind: config.LengthMethods})
+			addFields(n.Recv, config.LengthParams)
 		}
-		return n.Name.Name
-	case *ast.Field:
-		if len(n.Names) == 0 {
-			return ""
+	case *ast.FuncType:
+		addFields(n.TypeParams, config.LengthParams)
+		addFields(n.Params, config.LengthParams)
+		addFields(n.Results, config.LengthParams)
+	case *ast.StructType:
+		addFields(n.Fields, config.LengthFields)
+	case *ast.InterfaceType:
+		addFields(n.Methods, config.LengthMethods)
+	case *ast.GenDecl:
+		kind := config.LengthVars
+		if n.Tok == token.CONST {
+			kind = config.LengthConsts
 		}
-		return n.Names[0].Name
-	case *ast.FuncDecl:
-		if n.Name == nil {
-			return ""
+
+		for _, spec := range n.Specs {
+			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				for _, name := range valueSpec.Names {
+					idents = append(idents, lengthIdent{ident: name, kind: kind})
+				}
+			}
+		}
+	case *ast.AssignStmt:
+		if n.Tok == token.DEFINE {
+			addExprs(n.Lhs...)
+		}
+	case *ast.RangeStmt:
+		if n.Tok == token.DEFINE {
+			addExprs(n.Key, n.Value)
 		}
-		return n.Name.Name
 	}
-	return ""
+
+	return idents
 }
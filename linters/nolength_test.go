@@ -0,0 +1,76 @@
+package linters
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestNoLengthKinds(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+const constName = 1
+
+type Runner interface {
+	runMethod()
+}
+
+func Run() {
+	localVar := 1
+	_ = localVar
+}
+`)
+
+	cfg := &config.NoLength{Limits: config.LengthLimits{
+		Default: config.LengthLimit{MaxLength: 100, MaxSegments: 100},
+		Consts:  config.LengthLimit{MaxLength: 7},
+		Methods: config.LengthLimit{MaxLength: 8},
+		Vars:    config.LengthLimit{MaxLength: 6},
+	}}
+
+	require.Equal(t, []string{
+		"Maximum allowed length of identifier is 7 (now 9)",
+		"Maximum allowed length of identifier is 8 (now 9)",
+		"Maximum allowed length of identifier is 6 (now 8)",
+	}, getMessages(runNoLength(cfg, pkg)))
+}
+
+func TestNoLengthPackage(t *testing.T) {
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+	for _, filename := range []string{"b.go", "a.go"} {
+		file, err := parser.ParseFile(fset, filename, "package application\n", 0)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	cfg := &config.NoLength{Limits: config.LengthLimits{Packages: config.LengthLimit{MaxLength: 5, MaxSegments: 1}}}
+
+	// newPackage return package `application` with files in order.
+	newPackage := func(files ...*ast.File) *analysis.Package {
+		return &analysis.Package{Package: &packages.Package{Name: "application", PkgPath: "example.com/application", Fset: fset, Syntax: files}}
+	}
+
+	// name of package is checked once in the first file by name, issue does not depend on order of files.
+	issues := runNoLength(cfg, newPackage(files...))
+	require.Len(t, issues, 1)
+	require.Equal(t, "a.go", issues[0].Filename)
+	require.Equal(t, 1, issues[0].Line)
+	require.True(t, issues[0].Package)
+
+	reversed := runNoLength(cfg, newPackage(files[1], files[0]))
+	require.Equal(t, issues, reversed)
+
+	// excluded file is skipped.
+	cfg.ExcludeFiles = []string{"a.go"}
+	issues = runNoLength(cfg, newPackage(files...))
+	require.Len(t, issues, 1)
+	require.Equal(t, "b.go", issues[0].Filename)
+}
@@ -2,21 +2,22 @@ package linters
 
 import (
 	"bufio"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"os"
 	"path/filepath"
 	"regexp"
-	"slices"
 	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoNoLint = "NoNoLint"
+
 	messageNoNoLint = "a `nolint` comment forbidden to use"
 )
 
@@ -25,25 +26,34 @@ var noLintRe = regexp.MustCompile(`nolint:(.*?)(\s|$)`)
 // NewNoNoLint create instance linter for check func nolint.
 func NewNoNoLint() *analysis.Linter {
 	return &analysis.Linter{
-		Name: "NoNoLint",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
+		Name:     nameNoNoLint,
+		Doc:      "forbid usage of `nolint`",
+		Settings: config.NewNoNoLint,
+		Run: func(cfg *config.Config, pkgs []*analysis.Package) ([]analysis.Issue, error) {
+			settings, ok := cfg.Get(nameNoNoLint).(*config.NoNoLint)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type of settings %T of linter %s", cfg.Get(nameNoNoLint), nameNoNoLint)
+			}
+
 			issues := make([]analysis.Issue, 0)
 
-			if cfg.NoNoLint.Disable {
-				return issues
+			if settings.Disable {
+				return issues, nil
 			}
 
 			for _, pkg := range pkgs {
-				issues = append(issues, runNoNoLint(&cfg.NoNoLint, pkg)...)
+				issues = append(issues, runNoNoLint(settings, pkg)...)
 			}
 
-			return issues
+			return issues, nil
 		},
 	}
 }
 
 // TODO: check nolint in struct.
-func runNoNoLint(cfg *config.NoNoLint, pkg *packages.Package) []analysis.Issue {
+func runNoNoLint(cfg *config.NoNoLint, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	comments := make(map[string][]*ast.CommentGroup, len(pkg.Syntax))
 	for _, file := range pkg.Syntax {
 		comments[pkg.Fset.Position(file.Pos()).Filename] = file.Comments
@@ -58,21 +68,14 @@ func runNoNoLint(cfg *config.NoNoLint, pkg *packages.Package) []analysis.Issue {
 	var pkgIssues []analysis.Issue
 
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
-		var hash string
+		var legacy string
 
 		position := pkg.Fset.Position(node.Pos())
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
+		if cfg.IsExcludedFile(position.Filename) {
 			return
 		}
 
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
 		nFuncDecl, _ := node.(*ast.FuncDecl)
 
 		file := pkg.Fset.Position(node.Pos()).Filename
@@ -85,28 +88,31 @@ func runNoNoLint(cfg *config.NoNoLint, pkg *packages.Package) []analysis.Issue {
 
 			if nFuncDecl.Name != nil {
 				linters := strings.Split(res[1], ",")
-				if cfg.IsVerifyName(comment.Filename, nFuncDecl.Name.Name, linters) {
+				if cfg.IsVerifyName(nameNoNoLint, comment.Filename, nFuncDecl.Name.Name, linters) {
 					continue
 				}
 			}
 
 			if comment.IsDoc {
-				hash = analysis.GetHashFromBody(pkg.Fset, node)
+				legacy = analysis.GetHashFromBody(pkg, node)
 			} else {
-				hash = analysis.GetHashFromBodyByLine(pkg.Fset, node, comment.Line)
+				legacy = analysis.GetHashFromBodyByLine(pkg, node, comment.Line)
 			}
 
-			if cfg.IsVerifyHash(hash) {
+			hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoNoLint, pkg, node.Pos(), analysis.NormalizeText(comment.Text)))
+			if cfg.IsVerifyHash(nameNoNoLint, hash, legacy) {
 				continue
 			}
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  messageNoNoLint,
-				Line:     comment.Line,
-				Filename: comment.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    messageNoNoLint,
+				Line:       comment.Line,
+				Pos:        comment.Pos,
+				Filename:   comment.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	})
@@ -116,6 +122,7 @@ func runNoNoLint(cfg *config.NoNoLint, pkg *packages.Package) []analysis.Issue {
 
 type FuncComment struct {
 	Text     string
+	Pos      token.Pos
 	Line     int
 	Filename string
 	IsDoc    bool
@@ -133,6 +140,7 @@ func GetCommentsByFunc(fn *ast.FuncDecl, fileComments []*ast.CommentGroup, fset
 				position := fset.Position(c.Pos())
 				comments = append(comments, FuncComment{
 					Text:     c.Text,
+					Pos:      c.Pos(),
 					Line:     position.Line,
 					Filename: position.Filename,
 					IsDoc:    false,
@@ -146,6 +154,7 @@ func GetCommentsByFunc(fn *ast.FuncDecl, fileComments []*ast.CommentGroup, fset
 			position := fset.Position(comment.Pos())
 			comments = append(comments, FuncComment{
 				Text:     comment.Text,
+				Pos:      comment.Pos(),
 				Line:     position.Line,
 				Filename: position.Filename,
 				IsDoc:    true,
@@ -2,128 +2,124 @@ package linters
 
 import (
 	"fmt"
+	"go/token"
+	"path/filepath"
 	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoObject = "NoObject"
+
 	messageNoObjectPackageFile = "not found in package `%s` main file `%s.go`"
 	messageNoObjectScripts     = "package with name `scripts` allowed to use only in root"
 	messageNoObjectMain        = "file with name `main.go` allowed to use only in root"
 )
 
 func NewNoObject() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoObject",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoObject.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				issues = append(issues, runNoObjectPackageFile(&cfg.NoObject, pkg)...)
-				issues = append(issues, runNoObjectScripts(&cfg.NoObject, pkg)...)
-				issues = append(issues, runNoObjectMainFile(&cfg.NoObject, pkg)...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoObject, "check layout of packages, `main.go` and `scripts`",
+		runNoObjectPackageFile,
+		runNoObjectScripts,
+		runNoObjectMainFile,
+	)
 }
 
-func runNoObjectMainFile(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoObjectMainFile(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
 	var pkgIssues []analysis.Issue
 
 	gomodfile, err := config.ReadModFile()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	for _, file := range pkg.GoFiles {
 		filePath := analysis.GetPathRelative(file)
 		fileName := strings.ReplaceAll(filePath, fmt.Sprintf("%s/", gomodfile.Module.Mod.Path), "")
 
-		if fileName == "main.go" {
+		if fileName == "main.go" || cfg.IsExcludedFile(file) {
 			continue
 		}
 
 		if strings.HasSuffix(fileName, "main.go") {
-			hash := analysis.GetHashFromString(file)
-			if cfg.IsVerifyHash(hash) {
-				return pkgIssues
+			hash := analysis.GetFingerprintByPos(nameNoObject, pkg, token.NoPos, filepath.Base(file))
+			legacy := analysis.GetHashFromString(file)
+			if cfg.IsVerifyHash(nameNoObject, hash, legacy) {
+				continue
 			}
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  messageNoObjectMain,
-				Line:     1,
-				Filename: file,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    messageNoObjectMain,
+				Line:       1,
+				Package:    true,
+				Filename:   file,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	}
 
-	return pkgIssues
+	return pkgIssues, nil
 }
 
-func runNoObjectScripts(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoObjectScripts(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
 	var pkgIssues []analysis.Issue
 
 	gomodfile, err := config.ReadModFile()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	pkgName := strings.ReplaceAll(pkg.PkgPath, fmt.Sprintf("%s/", gomodfile.Module.Mod.Path), "")
 
 	if pkgName == "scripts" {
-		return pkgIssues
+		return pkgIssues, nil
 	}
 
 	if !strings.Contains(pkgName, "scripts") {
-		return pkgIssues
+		return pkgIssues, nil
 	}
 
 	if len(pkg.GoFiles) == 0 {
-		return pkgIssues
+		return pkgIssues, nil
 	}
 
-	hash := analysis.GetHashFromString(pkg.PkgPath)
-	if cfg.IsVerifyHash(hash) {
-		return pkgIssues
+	hash := analysis.GetFingerprintByPos(nameNoObject, pkg, token.NoPos, pkgName)
+	legacy := analysis.GetHashFromString(pkg.PkgPath)
+	if cfg.IsVerifyHash(nameNoObject, hash, legacy) {
+		return pkgIssues, nil
 	}
 
 	pkgIssues = append(pkgIssues, analysis.Issue{
-		Message:  messageNoObjectScripts,
-		Line:     1,
-		Filename: pkg.GoFiles[0],
-		Hash:     hash,
-		Severity: cfg.Severity,
-		Type:     cfg.Type,
+		Message:    messageNoObjectScripts,
+		Line:       1,
+		Package:    true,
+		Filename:   pkg.GoFiles[0],
+		Hash:       hash,
+		LegacyHash: legacy,
+		Severity:   cfg.Severity,
+		Type:       cfg.Type,
 	})
 
-	return pkgIssues
+	return pkgIssues, nil
 }
 
-func runNoObjectPackageFile(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoObjectPackageFile(cfg *config.DefaultLinter, pkg *analysis.Package) ([]analysis.Issue, error) {
 	var pkgIssues []analysis.Issue
 
 	isFind := false
 
 	gomodfile, err := config.ReadModFile()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	pkgName := strings.ReplaceAll(pkg.PkgPath, fmt.Sprintf("%s/", gomodfile.Module.Mod.Path), "")
-	if pkg.Name == "pkg" || pkgName == "scripts" {
-		return pkgIssues
+	if pkg.Name == "pkg" || pkgName == "scripts" || analysis.IsExternalTestPackage(pkg.Package) {
+		return pkgIssues, nil
 	}
 
 L:
@@ -135,20 +131,23 @@ L:
 	}
 
 	if !isFind {
-		hash := analysis.GetHashFromString(fmt.Sprintf("%s_%s", pkg.PkgPath, pkg.Name))
-		if cfg.IsVerifyHash(hash) {
-			return pkgIssues
+		hash := analysis.GetFingerprintByPos(nameNoObject, pkg, token.NoPos, pkg.Name)
+		legacy := analysis.GetHashFromString(fmt.Sprintf("%s_%s", pkg.PkgPath, pkg.Name))
+		if cfg.IsVerifyHash(nameNoObject, hash, legacy) {
+			return pkgIssues, nil
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  fmt.Sprintf(messageNoObjectPackageFile, pkg.PkgPath, pkg.Name),
-			Line:     1,
-			Filename: pkg.GoFiles[0],
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    fmt.Sprintf(messageNoObjectPackageFile, pkg.PkgPath, pkg.Name),
+			Line:       1,
+			Package:    true,
+			Filename:   pkg.GoFiles[0],
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	}
 
-	return pkgIssues
+	return pkgIssues, nil
 }
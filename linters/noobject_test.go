@@ -0,0 +1,32 @@
+package linters
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestNoObjectMainFileExclusions(t *testing.T) {
+	pkg := &analysis.Package{Package: &packages.Package{
+		Name:    "main",
+		PkgPath: "github.com/mirecl/golimiter/cmd/app",
+		GoFiles: []string{filepath.Join(config.GetRootDir(), "cmd", "app", "main.go")},
+	}}
+
+	issues, err := runNoObjectMainFile(&config.DefaultLinter{}, pkg)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	for _, cfg := range []*config.DefaultLinter{
+		{ExcludeFiles: []string{"cmd/*/main.go"}},
+		{ExcludeFolders: []string{"cmd"}},
+	} {
+		issues, err := runNoObjectMainFile(cfg, pkg)
+		require.NoError(t, err)
+		require.Empty(t, issues)
+	}
+}
@@ -13,10 +13,11 @@ import (
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoPrefix = "NoPrefix"
+
 	messageNoPrefixLambda                   = "a `lambda` funcs forbidden to use"
 	messageNoPrefixUpperFirstSymbolVariable = "please not use `%s` with first Upper symbol in variable"
 	messageNoPrefixUpperFirstSymbolParams   = "please not use `%s` with first Upper symbol in params"
@@ -29,27 +30,12 @@ var action = []string{"get", "new", "calc", "validate", "normalize",
 	"inference", "check", "max", "min", "find", "is", "any", "all"}
 
 // NewNoPrefix create instance linter for check func prefix.
-//
-//nolint:dupl
 func NewNoPrefix() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoPrefix",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoPrefix.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				issues = append(issues, runNoPrefix(&cfg.NoPrefix, pkg)...)
-				issues = append(issues, runNoCommonPrefix(&cfg.NoPrefix, pkg)...)
-				issues = append(issues, runNoPrefixUpperSymbol(&cfg.NoPrefix, pkg)...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoPrefix, "check prefixes and case of names in funcs and structs",
+		withoutError(runNoPrefix),
+		withoutError(runNoCommonPrefix),
+		withoutError(runNoPrefixUpperSymbol),
+	)
 }
 
 func GetParamsFromFunc(funcType *ast.FuncType) []string {
@@ -129,7 +115,9 @@ func GetVarFromBody(block *ast.BlockStmt) []BodyVariable {
 	return variables
 }
 
-func runNoPrefixUpperSymbol(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoPrefixUpperSymbol(cfg *config.DefaultLinter, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	var pkgIssues []analysis.Issue
 
 	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
@@ -145,29 +133,25 @@ func runNoPrefixUpperSymbol(cfg *config.DefaultLinter, pkg *packages.Package) []
 
 			position := pkg.Fset.Position(field.Position)
 
-			currentFile := analysis.GetPathRelative(position.Filename)
-			if slices.Contains(cfg.ExcludeFiles, currentFile) {
-				return
-			}
-
-			for _, folder := range cfg.ExcludeFolders {
-				if strings.HasPrefix(currentFile, folder) {
-					return
-				}
+			if isExcluded(cfg, nameNoPrefix, pkg, field.Position, field.Name) {
+				continue
 			}
 
-			hash := analysis.GetHashFromString(field.Name)
-			if cfg.IsVerifyHash(hash) {
+			hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoPrefix, pkg, field.Position, field.Name))
+			legacy := analysis.GetHashFromString(field.Name)
+			if cfg.IsVerifyHash(nameNoPrefix, hash, legacy) {
 				continue
 			}
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf(messageNoPrefixUpperFirstSymbolVariable, field.Name),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf(messageNoPrefixUpperFirstSymbolVariable, field.Name),
+				Line:       position.Line,
+				Pos:        field.Position,
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 
@@ -176,59 +160,56 @@ func runNoPrefixUpperSymbol(cfg *config.DefaultLinter, pkg *packages.Package) []
 		params := GetParamsFromFunc(decl.Type)
 
 		for _, field := range params {
-
-			currentFile := analysis.GetPathRelative(position.Filename)
-			if slices.Contains(cfg.ExcludeFiles, currentFile) {
-				return
+			if !unicode.IsUpper(rune(field[0])) {
+				continue
 			}
 
-			for _, folder := range cfg.ExcludeFolders {
-				if strings.HasPrefix(currentFile, folder) {
-					return
-				}
+			if isExcluded(cfg, nameNoPrefix, pkg, decl.Pos(), field) {
+				continue
 			}
 
-			if !unicode.IsUpper(rune(field[0])) {
+			hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoPrefix, pkg, decl.Pos(), "params "+field))
+			legacy := analysis.GetHashFromString(field)
+			if cfg.IsVerifyHash(nameNoPrefix, hash, legacy) {
 				continue
 			}
 
-			hash := analysis.GetHashFromString(field)
-
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf(messageNoPrefixUpperFirstSymbolParams, field),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf(messageNoPrefixUpperFirstSymbolParams, field),
+				Line:       position.Line,
+				Pos:        node.Pos(),
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 
 		for _, field := range GetReturnsFromFunc(decl.Type) {
-			currentFile := analysis.GetPathRelative(position.Filename)
-			if slices.Contains(cfg.ExcludeFiles, currentFile) {
-				return
+			if !unicode.IsUpper(rune(field[0])) {
+				continue
 			}
 
-			for _, folder := range cfg.ExcludeFolders {
-				if strings.HasPrefix(currentFile, folder) {
-					return
-				}
+			if isExcluded(cfg, nameNoPrefix, pkg, decl.Pos(), field) {
+				continue
 			}
 
-			if !unicode.IsUpper(rune(field[0])) {
+			hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoPrefix, pkg, decl.Pos(), "returns "+field))
+			legacy := analysis.GetHashFromString(field)
+			if cfg.IsVerifyHash(nameNoPrefix, hash, legacy) {
 				continue
 			}
 
-			hash := analysis.GetHashFromString(field)
-
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf(messageNoPrefixUpperFirstSymbolReturns, field),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf(messageNoPrefixUpperFirstSymbolReturns, field),
+				Line:       position.Line,
+				Pos:        node.Pos(),
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	})
@@ -236,7 +217,9 @@ func runNoPrefixUpperSymbol(cfg *config.DefaultLinter, pkg *packages.Package) []
 	return pkgIssues
 }
 
-func runNoPrefix(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoPrefix(cfg *config.DefaultLinter, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
 
 	inspect := inspector.New(pkg.Syntax)
@@ -247,23 +230,22 @@ func runNoPrefix(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Is
 		fn, _ := node.(*ast.FuncDecl)
 		position := pkg.Fset.Position(node.Pos())
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
-			return
-		}
+		if fn.Name == nil {
+			if isExcluded(cfg, nameNoPrefix, pkg, node.Pos(), "") {
+				return
+			}
 
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
+			hash := occurrences.Get(analysis.GetFingerprint(nameNoPrefix, pkg, fn))
+			if cfg.IsVerifyHash(nameNoPrefix, hash) {
 				return
 			}
-		}
 
-		if fn.Name == nil {
 			pkgIssues = append(pkgIssues, analysis.Issue{
 				Message:  messageNoPrefixLambda,
 				Line:     position.Line,
+				Pos:      node.Pos(),
 				Filename: position.Filename,
-				Hash:     "",
+				Hash:     hash,
 				Severity: cfg.Severity,
 				Type:     cfg.Type,
 			})
@@ -276,8 +258,13 @@ func runNoPrefix(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Is
 			return
 		}
 
-		hash := analysis.GetHashFromString(name)
-		if cfg.IsVerifyHash(hash) {
+		if isExcluded(cfg, nameNoPrefix, pkg, node.Pos(), name) {
+			return
+		}
+
+		hash := occurrences.Get(analysis.GetFingerprint(nameNoPrefix, pkg, fn.Name))
+		legacy := analysis.GetHashFromString(name)
+		if cfg.IsVerifyHash(nameNoPrefix, hash, legacy) {
 			return
 		}
 
@@ -287,37 +274,28 @@ func runNoPrefix(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Is
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  fmt.Sprintf("please rename func `%s` → `%s`", name, fixName),
-			Line:     position.Line,
-			Filename: position.Filename,
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    fmt.Sprintf("please rename func `%s` → `%s`", name, fixName),
+			Line:       position.Line,
+			Pos:        node.Pos(),
+			Filename:   position.Filename,
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	})
 
 	return pkgIssues
 }
 
-func runNoCommonPrefix(cfg *config.DefaultLinter, pkg *packages.Package) (pkgIssues []analysis.Issue) {
+func runNoCommonPrefix(cfg *config.DefaultLinter, pkg *analysis.Package) (pkgIssues []analysis.Issue) {
+	occurrences := analysis.Occurrences{}
+
 	inspect := inspector.New(pkg.Syntax)
 
 	nodeFilter := []ast.Node{(*ast.TypeSpec)(nil)}
 
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
-		position := pkg.Fset.Position(node.Pos())
-
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
-			return
-		}
-
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
 		typeSpec := node.(*ast.TypeSpec)
 
 		// only proceed with struct types
@@ -354,20 +332,29 @@ func runNoCommonPrefix(cfg *config.DefaultLinter, pkg *packages.Package) (pkgIss
 
 		// make issues
 		for _, fieldName := range found {
-			hash := analysis.GetHashFromString(typeName + fieldName)
-			if cfg.IsVerifyHash(hash) {
+			field := structType.Fields.List[fieldIdx[fieldName]]
+
+			if isExcluded(cfg, nameNoPrefix, pkg, field.Pos(), fieldName) {
 				continue
 			}
 
-			fieldPos := pkg.Fset.Position(structType.Fields.List[fieldIdx[fieldName]].Pos())
+			hash := occurrences.Get(analysis.GetFingerprint(nameNoPrefix, pkg, field.Names[0]))
+			legacy := analysis.GetHashFromString(typeName + fieldName)
+			if cfg.IsVerifyHash(nameNoPrefix, hash, legacy) {
+				continue
+			}
+
+			fieldPos := pkg.Fset.Position(field.Pos())
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf("field %s has common prefix (%s) with struct name (%s)", fieldName, commonPrefix, typeName),
-				Line:     fieldPos.Line,
-				Filename: fieldPos.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf("field %s has common prefix (%s) with struct name (%s)", fieldName, commonPrefix, typeName),
+				Line:       fieldPos.Line,
+				Pos:        field.Pos(),
+				Filename:   fieldPos.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	})
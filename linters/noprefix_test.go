@@ -0,0 +1,50 @@
+package linters
+
+import (
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoPrefixUpperSymbolExcludeHash(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+func run(Ctx int) (Err error) {
+	return nil
+}
+`)
+
+	issues := runNoPrefixUpperSymbol(&config.DefaultLinter{}, pkg)
+	require.Equal(t, []string{
+		"please not use `Ctx` with first Upper symbol in params",
+		"please not use `Err` with first Upper symbol in returns",
+	}, getMessages(issues))
+
+	// params and returns are excluded by fingerprint and by hash of previous versions (md5 of name).
+	cfg := &config.DefaultLinter{ExcludeHashs: []config.ExcludeHash{
+		{Hash: issues[0].Hash},
+		{Hash: analysis.GetHashFromString("Err")},
+	}}
+	require.Empty(t, runNoPrefixUpperSymbol(cfg, pkg))
+}
+
+func TestNoPrefixExcludeNames(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+func run(Ctx int) (Err error) {
+	Value := 1
+	return nil
+}
+`)
+
+	// name of enclosing func does not exclude issues inside of it.
+	cfg := &config.DefaultLinter{ExcludeNames: []config.ExcludeName{
+		{Name: "run", Path: "regex:.*"},
+		{Name: "regex:Ctx|Err", Path: "regex:.*"},
+	}}
+	require.Equal(t, []string{
+		"please not use `Value` with first Upper symbol in variable",
+	}, getMessages(runNoPrefixUpperSymbol(cfg, pkg)))
+}
@@ -3,42 +3,29 @@ package linters
 import (
 	"fmt"
 	"go/ast"
-	"slices"
+	"go/token"
 	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/packages"
 )
 
 const (
+	nameNoUnderscore = "NoUnderscore"
+
 	messageNoUnderscorePackages = "please not use symbol `_` in package name `%s` (https://go.dev/blog/package-names)"
 	messageNoUnderscoreVariable = "please not use symbol `_` in variable `%s`"
 	messageNoUnderscoreType     = "please not use symbol `_` in type `%s`"
 )
 
 func NewNoUnderscore() *analysis.Linter {
-	return &analysis.Linter{
-		Name: "NoUnderscore",
-		Run: func(cfg *config.Config, pkgs []*packages.Package) []analysis.Issue {
-			issues := make([]analysis.Issue, 0)
-
-			if cfg.NoUnderscore.Disable {
-				return issues
-			}
-
-			for _, pkg := range pkgs {
-				pkgIssues := runNoUnderscore(&cfg.NoUnderscore, pkg)
-				issues = append(issues, pkgIssues...)
-			}
-
-			return issues
-		},
-	}
+	return New(nameNoUnderscore, "forbid symbol `_` in names of packages, types and variables", withoutError(runNoUnderscore))
 }
 
-func runNoUnderscore(cfg *config.DefaultLinter, pkg *packages.Package) []analysis.Issue {
+func runNoUnderscore(cfg *config.DefaultLinter, pkg *analysis.Package) []analysis.Issue {
+	occurrences := analysis.Occurrences{}
+
 	var pkgIssues []analysis.Issue
 
 	for _, s := range pkg.Syntax {
@@ -61,37 +48,26 @@ func runNoUnderscore(cfg *config.DefaultLinter, pkg *packages.Package) []analysi
 				continue
 			}
 
-			position := pkg.Fset.Position(object.Pos())
-			hash := analysis.GetHashFromString(object.Name)
-			if cfg.IsVerifyHash(hash) {
-				return pkgIssues
-			}
-
-			currentFile := analysis.GetPathRelative(position.Filename)
-			if slices.Contains(cfg.ExcludeFiles, currentFile) {
+			if isExcluded(cfg, nameNoUnderscore, pkg, object.Pos(), object.Name) {
 				continue
 			}
 
-			isFind := false
-		L:
-			for _, folder := range cfg.ExcludeFolders {
-				if strings.HasPrefix(currentFile, folder) {
-					isFind = true
-					break L
-				}
-			}
-
-			if isFind {
+			position := pkg.Fset.Position(object.Pos())
+			hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoUnderscore, pkg, object.Pos(), object.Name))
+			legacy := analysis.GetHashFromString(object.Name)
+			if cfg.IsVerifyHash(nameNoUnderscore, hash, legacy) {
 				continue
 			}
 
 			pkgIssues = append(pkgIssues, analysis.Issue{
-				Message:  fmt.Sprintf(message, object.Name),
-				Line:     position.Line,
-				Filename: position.Filename,
-				Hash:     hash,
-				Severity: cfg.Severity,
-				Type:     cfg.Type,
+				Message:    fmt.Sprintf(message, object.Name),
+				Line:       position.Line,
+				Pos:        object.Pos(),
+				Filename:   position.Filename,
+				Hash:       hash,
+				LegacyHash: legacy,
+				Severity:   cfg.Severity,
+				Type:       cfg.Type,
 			})
 		}
 	}
@@ -119,67 +95,58 @@ func runNoUnderscore(cfg *config.DefaultLinter, pkg *packages.Package) []analysi
 			return
 		}
 
-		currentFile := analysis.GetPathRelative(position.Filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
+		if isExcluded(cfg, nameNoUnderscore, pkg, ident.Pos(), ident.Obj.Name) {
 			return
 		}
 
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				return
-			}
-		}
-
-		hash := analysis.GetHashFromString(ident.Obj.Name)
-		if cfg.IsVerifyHash(hash) {
+		hash := occurrences.Get(analysis.GetFingerprint(nameNoUnderscore, pkg, ident))
+		legacy := analysis.GetHashFromString(ident.Obj.Name)
+		if cfg.IsVerifyHash(nameNoUnderscore, hash, legacy) {
 			return
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  fmt.Sprintf(messageNoUnderscoreVariable, ident.Obj.Name),
-			Line:     position.Line,
-			Filename: position.Filename,
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    fmt.Sprintf(messageNoUnderscoreVariable, ident.Obj.Name),
+			Line:       position.Line,
+			Pos:        node.Pos(),
+			Filename:   position.Filename,
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	})
 
-	if !strings.Contains(pkg.Name, "_") {
+	// suffix `_test` is required for external test packages.
+	pkgName := pkg.Name
+	if analysis.IsExternalTestPackage(pkg.Package) {
+		pkgName = strings.TrimSuffix(pkgName, "_test")
+	}
+
+	if !strings.Contains(pkgName, "_") {
 		return pkgIssues
 	}
 
-	hash := analysis.GetHashFromString(pkg.Name)
-	if cfg.IsVerifyHash(hash) {
+	hash := occurrences.Get(analysis.GetFingerprintByPos(nameNoUnderscore, pkg, token.NoPos, pkg.Name))
+	legacy := analysis.GetHashFromString(pkg.Name)
+	if cfg.IsVerifyHash(nameNoUnderscore, hash, legacy) {
 		return pkgIssues
 	}
 
 	for _, filename := range pkg.GoFiles {
-		currentFile := analysis.GetPathRelative(filename)
-		if slices.Contains(cfg.ExcludeFiles, currentFile) {
-			continue
-		}
-
-		isFind := false
-	K:
-		for _, folder := range cfg.ExcludeFolders {
-			if strings.HasPrefix(currentFile, folder) {
-				isFind = true
-				break K
-			}
-		}
-
-		if isFind {
+		if cfg.IsExcludedFile(filename) {
 			continue
 		}
 
 		pkgIssues = append(pkgIssues, analysis.Issue{
-			Message:  fmt.Sprintf(messageNoUnderscorePackages, pkg.Name),
-			Line:     1,
-			Filename: filename,
-			Hash:     hash,
-			Severity: cfg.Severity,
-			Type:     cfg.Type,
+			Message:    fmt.Sprintf(messageNoUnderscorePackages, pkg.Name),
+			Line:       1,
+			Package:    true,
+			Filename:   filename,
+			Hash:       hash,
+			LegacyHash: legacy,
+			Severity:   cfg.Severity,
+			Type:       cfg.Type,
 		})
 	}
 
@@ -0,0 +1,61 @@
+package linters
+
+import (
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoUnderscoreExcludeHash(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+type a_b struct{}
+
+type c_d struct{}
+`)
+
+	// excluded type does not stop check of other types of file.
+	cfg := &config.DefaultLinter{ExcludeHashs: []config.ExcludeHash{{Hash: analysis.GetHashFromString("a_b")}}}
+	require.Equal(t, []string{"please not use symbol `_` in type `c_d`"}, getMessages(runNoUnderscore(cfg, pkg)))
+}
+
+func TestNoUnderscoreExclusionOrder(t *testing.T) {
+	pkg := parsePackage(t, `package app
+
+type a_b struct{}
+
+func Run() {
+	c_d := 1
+	_ = c_d
+}
+`)
+
+	cfg, err := config.ReadModuleFromBytes([]byte(`
+NoUnderscore:
+  ExcludeNames:
+    - Name: regex:a_b|c_d
+      Path: regex:.*
+  ExcludeHashs:
+    - Hash: ` + analysis.GetHashFromString("a_b") + `
+    - Hash: ` + analysis.GetHashFromString("c_d") + `
+`))
+	require.NoError(t, err)
+
+	settings, ok := cfg.Get(nameNoUnderscore).(*config.DefaultLinter)
+	require.True(t, ok)
+	require.Empty(t, runNoUnderscore(settings, pkg))
+
+	// names are checked before hashs for types and variables, so only names are used.
+	used := make(map[string]bool)
+	for _, exclusion := range cfg.GetExclusions() {
+		used[exclusion.String()] = cfg.IsUsed(exclusion)
+	}
+
+	require.Equal(t, map[string]bool{
+		"name regex:a_b|c_d in path regex:.*":       true,
+		"hash " + analysis.GetHashFromString("a_b"): false,
+		"hash " + analysis.GetHashFromString("c_d"): false,
+	}, used)
+}
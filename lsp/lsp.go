@@ -0,0 +1,506 @@
+// Package lsp is Language Server of golimiter over stdio.
+//
+// Server loads package of open file with unsaved buffers (packages.Config.Overlay),
+// publishes issues as diagnostics on open, change and save of file and offers
+// code action which adds hash of issue to `ExcludeHashs` in config file.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+// CommandExcludeHash command of code action, arguments are name of linter, hash and message of issue.
+const CommandExcludeHash = "golimiter.excludeHash"
+
+// Source of diagnostics in editor.
+const Source = "golimiter"
+
+// Options of language server.
+type Options struct {
+	// Version golimiter linter.
+	Version string
+	// ConfigPath path of config file relative to root of workspace.
+	ConfigPath string
+	// ConfigRequired config file must exist (e.g. it is passed by flag `-config`), otherwise it is optional.
+	ConfigRequired bool
+	// Tests analyze test files, linter can override it by `Tests` in config.
+	Tests bool
+	// Linters which are run for open files.
+	Linters []*analysis.Linter
+}
+
+// Server language server, it handles messages one by one.
+type Server struct {
+	opts      *Options
+	cfg       *config.Config
+	overlay   map[string][]byte
+	published map[string]bool
+	reader    *bufio.Reader
+	writer    io.Writer
+}
+
+// NewServer create language server.
+func NewServer(opts *Options) *Server {
+	return &Server{
+		opts:      opts,
+		cfg:       &config.Config{},
+		overlay:   make(map[string][]byte),
+		published: make(map[string]bool),
+	}
+}
+
+// Run serve messages from reader until notification `exit` or end of input.
+func (s *Server) Run(r io.Reader, w io.Writer) error {
+	s.reader = bufio.NewReader(r)
+	s.writer = w
+
+	for {
+		msg, err := s.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(msg)
+
+		// notification has no id and response.
+		if msg.ID == nil {
+			if err != nil {
+				s.logError(err)
+			}
+			continue
+		}
+
+		response := &message{JSONRPC: "2.0", ID: msg.ID, Result: result}
+		if err != nil {
+			response.Result = nil
+
+			var respErr *responseError
+			if !errors.As(err, &respErr) {
+				respErr = &responseError{Code: codeRequestFailed, Message: err.Error()}
+			}
+			response.Error = respErr
+		}
+
+		if err := s.write(response); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (json.RawMessage, error) {
+	switch msg.Method {
+	case "initialize":
+		return s.initialize(msg.Params)
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		return json.RawMessage("null"), nil
+	case "textDocument/didOpen":
+		return nil, s.didOpen(msg.Params)
+	case "textDocument/didChange":
+		return nil, s.didChange(msg.Params)
+	case "textDocument/didSave":
+		return nil, s.didSave(msg.Params)
+	case "textDocument/didClose":
+		return nil, s.didClose(msg.Params)
+	case "textDocument/codeAction":
+		return s.codeAction(msg.Params)
+	case "workspace/executeCommand":
+		return s.executeCommand(msg.Params)
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method `%s` not found", msg.Method)}
+}
+
+func (s *Server) initialize(params json.RawMessage) (json.RawMessage, error) {
+	var p initializeParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	if p.RootURI != "" {
+		root, err := uriToPath(p.RootURI)
+		if err != nil {
+			return nil, err
+		}
+
+		// packages are loaded from working dir, root dir and go.mod are cached by working dir (see config.GetRootDir).
+		if err := os.Chdir(root); err != nil {
+			return nil, err
+		}
+	}
+
+	configPath, err := filepath.Abs(s.opts.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	s.opts.ConfigPath = configPath
+
+	if err := s.loadConfig(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync:       textDocumentSyncOptions{OpenClose: true, Change: textDocumentSyncKindFull, Save: true},
+			CodeActionProvider:     true,
+			ExecuteCommandProvider: executeCommandOptions{Commands: []string{CommandExcludeHash}},
+		},
+		ServerInfo: serverInfo{Name: Source, Version: s.opts.Version},
+	})
+}
+
+func (s *Server) didOpen(params json.RawMessage) error {
+	var p didOpenTextDocumentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return invalidParams(err)
+	}
+
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	s.overlay[path] = []byte(p.TextDocument.Text)
+
+	return s.check(path)
+}
+
+func (s *Server) didChange(params json.RawMessage) error {
+	var p didChangeTextDocumentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return invalidParams(err)
+	}
+
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	// server requests full sync, so the last change is the whole document.
+	if len(p.ContentChanges) != 0 {
+		s.overlay[path] = []byte(p.ContentChanges[len(p.ContentChanges)-1].Text)
+	}
+
+	return s.check(path)
+}
+
+func (s *Server) didSave(params json.RawMessage) error {
+	var p didSaveTextDocumentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return invalidParams(err)
+	}
+
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	if path == s.opts.ConfigPath {
+		if err := s.loadConfig(); err != nil {
+			return err
+		}
+		return s.checkAll()
+	}
+
+	return s.check(path)
+}
+
+func (s *Server) didClose(params json.RawMessage) error {
+	var p didCloseTextDocumentParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return invalidParams(err)
+	}
+
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+
+	delete(s.overlay, path)
+
+	// diagnostics of closed file are updated by content on disk, unsaved file has no diagnostics.
+	if _, err := os.Stat(path); err != nil {
+		return s.publish(path, []diagnostic{})
+	}
+
+	return s.check(path)
+}
+
+func (s *Server) codeAction(params json.RawMessage) (json.RawMessage, error) {
+	var p codeActionParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	actions := make([]codeAction, 0, len(p.Context.Diagnostics))
+	for _, d := range p.Context.Diagnostics {
+		if d.Source != Source || d.Data.Hash == "" {
+			continue
+		}
+
+		title := fmt.Sprintf("Exclude %s issue (%s) in %s", d.Data.Linter, d.Data.Hash, filepath.Base(s.opts.ConfigPath))
+
+		actions = append(actions, codeAction{
+			Title:       title,
+			Kind:        "quickfix",
+			Diagnostics: []diagnostic{d},
+			Command: serverCommand{
+				Title:     title,
+				Command:   CommandExcludeHash,
+				Arguments: []string{d.Data.Linter, d.Data.Hash, d.Message},
+			},
+		})
+	}
+
+	return json.Marshal(actions)
+}
+
+func (s *Server) executeCommand(params json.RawMessage) (json.RawMessage, error) {
+	var p executeCommandParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	if p.Command != CommandExcludeHash || len(p.Arguments) != 3 {
+		return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown command `%s`", p.Command)}
+	}
+
+	exclude := config.ExcludeHash{Hash: p.Arguments[1], Comment: p.Arguments[2]}
+	if err := config.AddExcludeHash(s.opts.ConfigPath, p.Arguments[0], exclude); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadConfig(); err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage("null"), s.checkAll()
+}
+
+func (s *Server) loadConfig() error {
+	read := config.ReadFromFileOrDefault
+	if s.opts.ConfigRequired {
+		read = config.ReadFromFile
+	}
+
+	cfg, err := read(s.opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	s.cfg = cfg
+	return nil
+}
+
+// checkAll check packages of all open files.
+func (s *Server) checkAll() error {
+	dirs := make(map[string]bool)
+	for _, path := range slices.Sorted(maps.Keys(s.overlay)) {
+		if dirs[filepath.Dir(path)] || filepath.Ext(path) != ".go" {
+			continue
+		}
+		dirs[filepath.Dir(path)] = true
+
+		if err := s.check(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check run linters for package of file and publish diagnostics for all files of package.
+// Diagnostics of package with errors are kept until package is fixed.
+func (s *Server) check(path string) error {
+	if filepath.Ext(path) != ".go" {
+		return nil
+	}
+
+	opts := &analysis.Options{
+		Patterns: []string{"file=" + path},
+		Tests:    s.opts.Tests,
+		Builds:   s.cfg.Builds,
+		Overlay:  s.overlay,
+	}
+
+	allIssues, err := analysis.Run(s.cfg, opts, s.opts.Linters...)
+
+	var brokenErr *analysis.BrokenPackagesError
+	if errors.As(err, &brokenErr) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	diagnostics := make(map[string][]diagnostic)
+
+	for _, linter := range s.opts.Linters {
+		for _, issue := range allIssues[linter.Name] {
+			diagnostics[issue.Filename] = append(diagnostics[issue.Filename], newDiagnostic(linter.Name, issue))
+		}
+	}
+
+	// clear diagnostics of files without issues.
+	for published := range s.published {
+		if filepath.Dir(published) == filepath.Dir(path) && diagnostics[published] == nil {
+			diagnostics[published] = []diagnostic{}
+		}
+	}
+
+	for _, filename := range slices.Sorted(maps.Keys(diagnostics)) {
+		if err := s.publish(filename, diagnostics[filename]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publish send diagnostics of file, empty diagnostics clear issues of file in editor.
+func (s *Server) publish(filename string, diagnostics []diagnostic) error {
+	if len(diagnostics) == 0 {
+		delete(s.published, filename)
+	} else {
+		s.published[filename] = true
+	}
+
+	body, err := json.Marshal(publishDiagnosticsParams{URI: pathToURI(filename), Diagnostics: diagnostics})
+	if err != nil {
+		return err
+	}
+
+	return s.notify("textDocument/publishDiagnostics", body)
+}
+
+func newDiagnostic(linter string, issue analysis.Issue) diagnostic {
+	line := max(issue.Line-1, 0)
+
+	return diagnostic{
+		Range: textRange{
+			Start: position{Line: line},
+			End:   position{Line: line + 1},
+		},
+		Severity: GetSeverity(issue.Severity),
+		Code:     linter,
+		Source:   Source,
+		Message:  issue.Message,
+		Data:     diagnosticData{Linter: linter, Hash: issue.Hash},
+	}
+}
+
+// GetSeverity map severity of issue to severity of diagnostic.
+func GetSeverity(severity string) int {
+	switch severity {
+	case config.SeverityMajor:
+		return DiagnosticSeverityWarning
+	case config.SeverityMinor:
+		return DiagnosticSeverityInformation
+	case config.SeverityInfo:
+		return DiagnosticSeverityHint
+	default:
+		return DiagnosticSeverityError
+	}
+}
+
+func (s *Server) logError(err error) {
+	body, _ := json.Marshal(logMessageParams{Type: messageTypeError, Message: err.Error()})
+	_ = s.notify("window/logMessage", body)
+}
+
+func (s *Server) notify(method string, params json.RawMessage) error {
+	return s.write(&message{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// read message with header `Content-Length`.
+func (s *Server) read() (*message, error) {
+	length := -1
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		if value, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("invalid header `%s`: %w", line, err)
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, errors.New("missing header `Content-Length`")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, body); err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (s *Server) write(msg *message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+func invalidParams(err error) error {
+	return &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %s", err)}
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported uri `%s`", uri)
+	}
+
+	return filepath.FromSlash(u.Path), nil
+}
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
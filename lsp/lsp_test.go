@@ -0,0 +1,188 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/linters"
+	"github.com/stretchr/testify/require"
+)
+
+// client of language server over pipes, messages are framed by the same code as in server.
+type client struct {
+	t    *testing.T
+	conn *Server
+	id   int
+}
+
+// newClient run server and initialize it with root dir of workspace.
+func newClient(t *testing.T, root string) *client {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	server := NewServer(&Options{ConfigPath: ".golimiter.yaml", Linters: []*analysis.Linter{linters.NewNoDefer()}})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Run(serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+
+	t.Cleanup(func() {
+		_ = clientWriter.Close()
+		require.NoError(t, <-done)
+	})
+
+	c := &client{t: t, conn: &Server{reader: bufio.NewReader(clientReader), writer: clientWriter}}
+
+	params, err := json.Marshal(initializeParams{RootURI: pathToURI(root)})
+	require.NoError(t, err)
+
+	var result initializeResult
+	require.NoError(t, json.Unmarshal(c.request("initialize", params), &result))
+	require.Equal(t, []string{CommandExcludeHash}, result.Capabilities.ExecuteCommandProvider.Commands)
+
+	return c
+}
+
+// notify send notification to server.
+func (c *client) notify(method string, params json.RawMessage) {
+	require.NoError(c.t, c.conn.write(&message{JSONRPC: "2.0", Method: method, Params: params}))
+}
+
+// request send request to server and return result of response.
+func (c *client) request(method string, params json.RawMessage) json.RawMessage {
+	return c.readResult(c.send(method, params))
+}
+
+// send send request to server and return its id, notifications of server are read before response.
+func (c *client) send(method string, params json.RawMessage) json.RawMessage {
+	c.id++
+	id := json.RawMessage(strconv.Itoa(c.id))
+	require.NoError(c.t, c.conn.write(&message{JSONRPC: "2.0", ID: id, Method: method, Params: params}))
+	return id
+}
+
+// readResult read response of request with id and return its result.
+func (c *client) readResult(id json.RawMessage) json.RawMessage {
+	msg, err := c.conn.read()
+	require.NoError(c.t, err)
+	require.Equal(c.t, id, msg.ID, "unexpected message %s %s", msg.Method, msg.Params)
+	require.Nil(c.t, msg.Error)
+
+	return msg.Result
+}
+
+// readDiagnostics read notification `textDocument/publishDiagnostics` from server.
+func (c *client) readDiagnostics() publishDiagnosticsParams {
+	msg, err := c.conn.read()
+	require.NoError(c.t, err)
+	require.Equal(c.t, "textDocument/publishDiagnostics", msg.Method, "unexpected message %s", msg.Params)
+
+	var params publishDiagnosticsParams
+	require.NoError(c.t, json.Unmarshal(msg.Params, &params))
+
+	return params
+}
+
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module example.com/app\n\ngo 1.23\n",
+		"app.go":          "package app\n\nfunc A() {}\n",
+		".golimiter.yaml": "module:\n  example.com/app:\n    NoDefer:\n      Info:\n        Severity: MAJOR\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o600))
+	}
+
+	c := newClient(t, root)
+	path := filepath.Join(root, "app.go")
+
+	// unsaved buffer is analyzed, severity of issue is mapped to severity of diagnostic.
+	params, err := json.Marshal(didOpenTextDocumentParams{TextDocument: documentItem{
+		URI:  pathToURI(path),
+		Text: "package app\n\nfunc A() {\n\tdefer println()\n}\n",
+	}})
+	require.NoError(t, err)
+	c.notify("textDocument/didOpen", params)
+
+	published := c.readDiagnostics()
+	require.Equal(t, pathToURI(path), published.URI)
+	require.Len(t, published.Diagnostics, 1)
+
+	d := published.Diagnostics[0]
+	require.Equal(t, DiagnosticSeverityWarning, d.Severity)
+	require.Equal(t, textRange{Start: position{Line: 3}, End: position{Line: 4}}, d.Range)
+	require.Equal(t, "NoDefer", d.Data.Linter)
+	require.NotEmpty(t, d.Data.Hash)
+
+	// code action excludes hash of issue.
+	params, err = json.Marshal(codeActionParams{
+		TextDocument: textDocumentIdentifier{URI: pathToURI(path)},
+		Context:      codeActionContext{Diagnostics: published.Diagnostics},
+	})
+	require.NoError(t, err)
+
+	var actions []codeAction
+	require.NoError(t, json.Unmarshal(c.request("textDocument/codeAction", params), &actions))
+	require.Len(t, actions, 1)
+	require.Equal(t, CommandExcludeHash, actions[0].Command.Command)
+	require.Equal(t, []string{"NoDefer", d.Data.Hash, d.Message}, actions[0].Command.Arguments)
+
+	// issue is excluded after command of code action.
+	params, err = json.Marshal(executeCommandParams{Command: actions[0].Command.Command, Arguments: actions[0].Command.Arguments})
+	require.NoError(t, err)
+	id := c.send("workspace/executeCommand", params)
+
+	require.Empty(t, c.readDiagnostics().Diagnostics)
+	require.JSONEq(t, "null", string(c.readResult(id)))
+
+	body, err := os.ReadFile(filepath.Join(root, ".golimiter.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(body), "Hash: "+d.Data.Hash)
+}
+
+func TestServerDidClose(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.23\n",
+		"app.go": "package app\n\nfunc A() {}\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o600))
+	}
+
+	c := newClient(t, root)
+
+	for _, name := range []string{"app.go", "new.go"} {
+		path := filepath.Join(root, name)
+
+		params, err := json.Marshal(didOpenTextDocumentParams{TextDocument: documentItem{
+			URI:  pathToURI(path),
+			Text: "package app\n\nfunc B() {\n\tdefer println()\n}\n",
+		}})
+		require.NoError(t, err)
+		c.notify("textDocument/didOpen", params)
+		require.Len(t, c.readDiagnostics().Diagnostics, 1)
+
+		// diagnostics of unsaved buffer are cleared after close of file, content on disk has no issues.
+		params, err = json.Marshal(didCloseTextDocumentParams{TextDocument: textDocumentIdentifier{URI: pathToURI(path)}})
+		require.NoError(t, err)
+		c.notify("textDocument/didClose", params)
+
+		published := c.readDiagnostics()
+		require.Equal(t, pathToURI(path), published.URI)
+		require.Empty(t, published.Diagnostics)
+	}
+}
@@ -1,48 +1,332 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/mirecl/golimiter/analysis"
 	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/diff"
 	"github.com/mirecl/golimiter/linters"
+	"github.com/mirecl/golimiter/lsp"
+	"github.com/mirecl/golimiter/report"
 )
 
 // Version golimiter linter.
 const Version string = "0.8.3"
 
+// Exit codes of golimiter.
+const (
+	ExitCodeSuccess = 0 // no issues found
+	ExitCodeIssues  = 1 // issues found at or above severity `-fail-on`
+	ExitCodeError   = 2 // invalid flags, config or failed load packages
+)
+
+// commands subcommands of golimiter, e.g. `golimiter lsp`.
+var commands = map[string]func(args []string) int{
+	"lsp":            runLSP,
+	"baseline":       runBaseline,
+	"exclusions":     runExclusions,
+	"watch":          runWatch,
+	"migrate-hashes": runMigrateHashs,
+	"config":         runConfig,
+}
+
 func main() {
-	jsonFlag := flag.Bool("json", false, "format report")
-	versionFlag := flag.Bool("version", false, "version golimiter")
-	configFlag := flag.String("config", ".golimiter.yaml", "path config file")
+	os.Exit(run())
+}
 
-	flag.Parse()
+func run() int {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			return command(os.Args[2:])
+		}
+	}
 
-	if *versionFlag {
+	return runCheck(os.Args[1:])
+}
+
+func runCheck(args []string) int {
+	check := addCheckFlags(flag.CommandLine)
+	flag.Usage = printUsage
+
+	_ = flag.CommandLine.Parse(args)
+
+	if *check.version {
 		fmt.Printf("golimiter %s\n", Version)
-		return
+		return ExitCodeSuccess
 	}
 
-	cfg, err := config.ReadFromFile(*configFlag)
+	writer, err := check.getWriter()
 	if err != nil {
-		panic(err)
+		return exitWithError(err)
 	}
 
-	allIssues := analysis.Run(cfg, linters.All...)
+	cfg, opts, err := check.load.load(flag.Args())
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	staged, changes, err := check.readChanges(opts)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// nothing is staged.
+	if staged != nil && len(staged.Files) == 0 {
+		return ExitCodeSuccess
+	}
+
+	allIssues, err := analysis.Run(cfg, opts, linters.All...)
+
+	var diagnosticsErr *analysis.BrokenPackagesError
+	if err != nil && !errors.As(err, &diagnosticsErr) {
+		return exitWithError(err)
+	}
+
+	if changes != nil {
+		allIssues = changes.Filter(allIssues)
+	}
+
+	if staged != nil {
+		allIssues = staged.Filter(allIssues)
+	}
 
-	if *jsonFlag {
-		if allIssuesBytes, err := json.Marshal(allIssues); err == nil {
-			fmt.Println(string(allIssuesBytes))
+	r := &report.Report{Version: Version, Config: cfg, Linters: linters.All, Issues: allIssues}
+	if err := writer(os.Stdout, r); err != nil {
+		return exitWithError(err)
+	}
+
+	if diagnosticsErr != nil {
+		return exitWithError(diagnosticsErr)
+	}
+
+	if isFailed(allIssues, *check.failOn) {
+		return ExitCodeIssues
+	}
+
+	return ExitCodeSuccess
+}
+
+// checkFlags flags of check of packages (command by default).
+type checkFlags struct {
+	json         *bool
+	format       *string
+	failOn       *string
+	version      *bool
+	newFromRev   *string
+	newFromPatch *string
+	staged       *bool
+	load         *loadFlags
+}
+
+func addCheckFlags(flags *flag.FlagSet) *checkFlags {
+	return &checkFlags{
+		json:         flags.Bool("json", false, "format report (alias for -format json)"),
+		format:       flags.String("format", "text", fmt.Sprintf("format report (%s)", strings.Join(getFormats(), ", "))),
+		failOn:       flags.String("fail-on", config.SeverityInfo, fmt.Sprintf("minimal severity of issues to fail (%s)", strings.Join(config.Severities, ", "))),
+		version:      flags.Bool("version", false, "version golimiter"),
+		newFromRev:   flags.String("new-from-rev", "", "report only issues on lines changed since git revision (e.g. origin/main)"),
+		newFromPatch: flags.String("new-from-patch", "", "report only issues on lines added by patch file"),
+		staged:       flags.Bool("staged", false, "analyze only packages of staged files with content from git index and report issues of these files"),
+		load:         addLoadFlags(flags),
+	}
+}
+
+// printUsage print usage of golimiter and its commands.
+func printUsage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: golimiter [flags] [packages]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter lsp [flags]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter baseline [flags] [packages]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter exclusions audit [flags] [packages]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter watch [flags]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter migrate-hashes [flags] [packages]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter config validate [flags]\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "       golimiter config schema\n")
+	flag.PrintDefaults()
+}
+
+// getWriter check values of flags and return writer of report in format of flags.
+func (f *checkFlags) getWriter() (report.Writer, error) {
+	if *f.json {
+		*f.format = "json"
+	}
+
+	writer, ok := report.Formats[*f.format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format report `%s`", *f.format)
+	}
+
+	if !config.IsSeverity(*f.failOn) {
+		return nil, fmt.Errorf("unknown severity `%s` in flag -fail-on", *f.failOn)
+	}
+
+	if *f.newFromRev != "" && *f.newFromPatch != "" {
+		return nil, errors.New("flags -new-from-rev and -new-from-patch can't be used together")
+	}
+
+	return writer, nil
+}
+
+// readChanges return staged files by flag `-staged` (packages of staged files are set to options)
+// and changed lines by flags `-new-from-rev` or `-new-from-patch`, nil is returned for flags which are not set.
+func (f *checkFlags) readChanges(opts *analysis.Options) (*diff.Staged, *diff.Changes, error) {
+	var staged *diff.Staged
+	var changes *diff.Changes
+	var err error
+
+	if *f.staged {
+		if len(opts.Patterns) != 0 {
+			return nil, nil, errors.New("flag -staged can't be used with packages")
+		}
+
+		if staged, err = diff.GetStaged(); err != nil || len(staged.Files) == 0 {
+			return staged, nil, err
+		}
+
+		opts.Patterns = staged.Dirs()
+		opts.Overlay = staged.Overlay
+	}
+
+	switch {
+	case *f.newFromRev != "":
+		changes, err = diff.FromRev(*f.newFromRev)
+	case *f.newFromPatch != "":
+		changes, err = diff.FromPatch(*f.newFromPatch)
+	}
+
+	return staged, changes, err
+}
+
+func runLSP(args []string) int {
+	flags := flag.NewFlagSet("golimiter lsp", flag.ExitOnError)
+	configFlag := flags.String("config", ".golimiter.yaml", "path config file (relative to root of workspace)")
+	testsFlag := flags.Bool("tests", false, "analyze test files (linter can override it by Tests in config)")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter lsp [flags]\n\nLanguage Server over stdio.\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	server := lsp.NewServer(&lsp.Options{
+		Version:        Version,
+		ConfigPath:     *configFlag,
+		ConfigRequired: isFlagSet(flags, "config"),
+		Tests:          *testsFlag,
+		Linters:        linters.All,
+	})
+
+	if err := server.Run(os.Stdin, os.Stdout); err != nil {
+		return exitWithError(err)
+	}
+
+	return ExitCodeSuccess
+}
+
+// loadFlags flags of loading config and packages shared by commands.
+type loadFlags struct {
+	set     *flag.FlagSet
+	config  *string
+	dir     *string
+	tests   *bool
+	tags    *string
+	goos    *string
+	goarch  *string
+	jobs    *int
+	verbose *bool
+}
+
+func addLoadFlags(flags *flag.FlagSet) *loadFlags {
+	return &loadFlags{
+		set:     flags,
+		config:  flags.String("config", ".golimiter.yaml", "path config file"),
+		dir:     flags.String("C", "", "change to dir before running"),
+		tests:   flags.Bool("tests", false, "analyze test files (linter can override it by Tests in config)"),
+		tags:    flags.String("tags", "", "comma-separated list of build tags"),
+		goos:    flags.String("goos", "", "target operating system (GOOS)"),
+		goarch:  flags.String("goarch", "", "target architecture (GOARCH)"),
+		jobs:    flags.Int("j", runtime.GOMAXPROCS(0), "number of (linter × package) units running in parallel"),
+		verbose: flags.Bool("v", false, "verbose output (e.g. number of skipped generated and vendor files)"),
+	}
+}
+
+// load change dir, read config and return options of analysis for patterns of packages.
+func (f *loadFlags) load(patterns []string) (*config.Config, *analysis.Options, error) {
+	if *f.dir != "" {
+		if err := os.Chdir(*f.dir); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cfg, err := f.readConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := &analysis.Options{Patterns: patterns, Tests: *f.tests, Jobs: *f.jobs, Builds: cfg.Builds}
+	if *f.verbose {
+		opts.Log = os.Stderr
+	}
+
+	if *f.tags != "" || *f.goos != "" || *f.goarch != "" {
+		build := config.Build{GOOS: *f.goos, GOARCH: *f.goarch}
+		if *f.tags != "" {
+			build.Tags = strings.Split(*f.tags, ",")
 		}
-		return
+		opts.Builds = []config.Build{build}
+	}
+
+	return cfg, opts, nil
+}
+
+// readConfig read config, file passed by flag `-config` must exist, default file is optional.
+func (f *loadFlags) readConfig() (*config.Config, error) {
+	if isFlagSet(f.set, "config") {
+		return config.ReadFromFile(*f.config)
 	}
 
-	for linter, issues := range allIssues {
+	return config.ReadFromFileOrDefault(*f.config)
+}
+
+// isFlagSet check flag is passed in command line.
+func isFlagSet(set *flag.FlagSet, name string) bool {
+	found := false
+	set.Visit(func(flag *flag.Flag) {
+		if flag.Name == name {
+			found = true
+		}
+	})
+	return found
+}
+
+func isFailed(allIssues map[string][]analysis.Issue, failOn string) bool {
+	for _, issues := range allIssues {
 		for _, issue := range issues {
-			position := fmt.Sprintf("%s:%v", analysis.GetPathRelative(issue.Filename), issue.Line)
-			fmt.Printf("%s \033[31m%s: %s. \033[0m\033[30m(%s)\033[0m\n", position, linter, issue.Message, issue.Hash)
+			if config.IsSeverityAtLeast(issue.Severity, failOn) {
+				return true
+			}
 		}
 	}
+	return false
+}
+
+func exitWithError(err error) int {
+	fmt.Fprintf(os.Stderr, "golimiter: %s\n", err)
+	return ExitCodeError
+}
+
+func getFormats() []string {
+	formats := make([]string, 0, len(report.Formats))
+	for format := range report.Formats {
+		formats = append(formats, format)
+	}
+	slices.Sort(formats)
+	return formats
 }
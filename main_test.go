@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsFailed(t *testing.T) {
+	issues := func(severities ...string) map[string][]analysis.Issue {
+		allIssues := map[string][]analysis.Issue{"NoDefer": nil}
+		for _, severity := range severities {
+			allIssues["NoDefer"] = append(allIssues["NoDefer"], analysis.Issue{Severity: severity})
+		}
+		return allIssues
+	}
+
+	tests := []struct {
+		name      string
+		allIssues map[string][]analysis.Issue
+		failOn    string
+		failed    bool
+	}{
+		{name: "no issues", allIssues: issues(), failOn: config.SeverityInfo, failed: false},
+		{name: "issue at severity", allIssues: issues(config.SeverityMajor), failOn: config.SeverityMajor, failed: true},
+		{name: "issue above severity", allIssues: issues(config.SeverityCritical), failOn: config.SeverityMajor, failed: true},
+		{name: "issues below severity", allIssues: issues(config.SeverityMinor, config.SeverityInfo), failOn: config.SeverityMajor, failed: false},
+		{name: "issue without severity is blocker", allIssues: issues(""), failOn: config.SeverityBlocker, failed: true},
+		{name: "issue of unknown severity", allIssues: issues("UNKNOWN"), failOn: config.SeverityBlocker, failed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.failed, isFailed(tt.allIssues, tt.failOn))
+		})
+	}
+}
+
+func TestCheckFlagsGetWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		format string
+		err    string
+	}{
+		{name: "default", args: nil, format: "text"},
+		{name: "format", args: []string{"-format", "sarif", "-fail-on", config.SeverityMajor}, format: "sarif"},
+		{name: "json", args: []string{"-json"}, format: "json"},
+		{name: "unknown format", args: []string{"-format", "xml"}, err: "unknown format report `xml`"},
+		{name: "unknown severity", args: []string{"-fail-on", "major"}, err: "unknown severity `major` in flag -fail-on"},
+		{name: "new from rev and patch", args: []string{"-new-from-rev", "HEAD", "-new-from-patch", "a.patch"}, err: "flags -new-from-rev and -new-from-patch can't be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("golimiter", flag.ContinueOnError)
+			check := addCheckFlags(flags)
+			require.NoError(t, flags.Parse(tt.args))
+
+			writer, err := check.getWriter()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, writer)
+			require.Equal(t, tt.format, *check.format)
+		})
+	}
+}
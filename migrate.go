@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+)
+
+// runMigrateHashs run all linters without `ExcludeHashs` and replace hashs of previous scheme
+// in `ExcludeHashs` of config by fingerprints of the same issues.
+func runMigrateHashs(args []string) int {
+	flags := flag.NewFlagSet("golimiter migrate-hashes", flag.ExitOnError)
+	load := addLoadFlags(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter migrate-hashes [flags] [packages]\n\n")
+		fmt.Fprintf(flags.Output(), "Replace hashs of previous scheme in ExcludeHashs of config file by fingerprints.\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	cfg, opts, err := load.load(flags.Args())
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// migration of broken packages is not complete, so config is not changed.
+	allIssues, err := analysis.Run(cfg.WithoutExcludeHashs(), opts, linters.All...)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	excluded := make(map[string]map[string]bool)
+	for _, exclusion := range cfg.GetExclusions() {
+		if exclusion.Kind != config.ExclusionHash {
+			continue
+		}
+
+		if excluded[exclusion.Linter] == nil {
+			excluded[exclusion.Linter] = make(map[string]bool)
+		}
+		excluded[exclusion.Linter][exclusion.Hash] = true
+	}
+
+	hashs := make(map[string]map[string][]string)
+	fingerprints := make(map[string]bool)
+
+	for _, linter := range linters.All {
+		for _, issue := range allIssues[linter.Name] {
+			fingerprints[linter.Name+"_"+issue.Hash] = true
+
+			if !excluded[linter.Name][issue.LegacyHash] || issue.LegacyHash == issue.Hash {
+				continue
+			}
+
+			if hashs[linter.Name] == nil {
+				hashs[linter.Name] = make(map[string][]string)
+			}
+
+			if !slices.Contains(hashs[linter.Name][issue.LegacyHash], issue.Hash) {
+				hashs[linter.Name][issue.LegacyHash] = append(hashs[linter.Name][issue.LegacyHash], issue.Hash)
+			}
+		}
+	}
+
+	count, err := config.MigrateExcludeHashs(*load.config, hashs)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	// hashs without issues are not matched (e.g. issue is fixed or package is not analyzed).
+	for _, exclusion := range cfg.GetExclusions() {
+		if exclusion.Kind != config.ExclusionHash || fingerprints[exclusion.Linter+"_"+exclusion.Hash] {
+			continue
+		}
+
+		if _, ok := hashs[exclusion.Linter][exclusion.Hash]; !ok {
+			fmt.Fprintf(os.Stderr, "%s %s: %s is not migrated, issue is not found\n", *load.config, exclusion.Linter, exclusion)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "golimiter: %d hashs migrated in %s\n", count, *load.config)
+
+	return ExitCodeSuccess
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+const checkstyleVersion = "5.0"
+
+type checkstyleOutput struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Checkstyle write report in format Checkstyle XML grouped by file.
+func Checkstyle(w io.Writer, r *Report) error {
+	files := make(map[string]*checkstyleFile)
+
+	for _, linter := range r.Linters {
+		for _, issue := range r.Issues[linter.Name] {
+			filename := analysis.GetPathRelative(issue.Filename)
+
+			file, ok := files[filename]
+			if !ok {
+				file = &checkstyleFile{Name: filename}
+				files[filename] = file
+			}
+
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     max(issue.Line, 1),
+				Severity: GetCheckstyleSeverity(issue.Severity),
+				Message:  issue.Message,
+				Source:   linter.Name,
+			})
+		}
+	}
+
+	output := checkstyleOutput{Version: checkstyleVersion, Files: make([]*checkstyleFile, 0, len(files))}
+	for _, file := range files {
+		output.Files = append(output.Files, file)
+	}
+
+	slices.SortFunc(output.Files, func(a, b *checkstyleFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return writeXML(w, func(encoder *xml.Encoder) error {
+		return encoder.Encode(output)
+	})
+}
+
+// GetCheckstyleSeverity mapping severity of issue to severity Checkstyle.
+func GetCheckstyleSeverity(severity string) string {
+	switch severity {
+	case config.SeverityBlocker, config.SeverityCritical:
+		return "error"
+	case config.SeverityMajor:
+		return "warning"
+	case config.SeverityMinor, config.SeverityInfo:
+		return "info"
+	}
+	return "error"
+}
+
+func writeXML(w io.Writer, encode func(*xml.Encoder) error) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encode(encoder); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
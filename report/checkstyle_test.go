@@ -0,0 +1,7 @@
+package report
+
+import "testing"
+
+func TestCheckstyle(t *testing.T) {
+	requireGolden(t, "checkstyle.golden.xml", Checkstyle)
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// GitHub write report as GitHub Actions workflow commands, so issues are shown inline in pull requests.
+// Please more info in https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func GitHub(w io.Writer, r *Report) error {
+	for _, linter := range r.Linters {
+		for _, issue := range r.Issues[linter.Name] {
+			_, err := fmt.Fprintf(w, "::%s file=%s,line=%d,title=%s::%s (%s)\n",
+				GetGitHubLevel(issue.Severity),
+				githubPropertyEscaper.Replace(filepath.ToSlash(analysis.GetPathRelative(issue.Filename))),
+				max(issue.Line, 1),
+				githubPropertyEscaper.Replace(linter.Name),
+				githubDataEscaper.Replace(issue.Message),
+				issue.Hash,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetGitHubLevel mapping severity of issue to level of workflow command.
+func GetGitHubLevel(severity string) string {
+	switch severity {
+	case config.SeverityBlocker, config.SeverityCritical:
+		return "error"
+	case config.SeverityMajor:
+		return "warning"
+	case config.SeverityMinor, config.SeverityInfo:
+		return "notice"
+	}
+	return "error"
+}
@@ -0,0 +1,43 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub(t *testing.T) {
+	r := &Report{
+		Linters: []*analysis.Linter{{Name: "NoLength"}, {Name: "NoObject"}},
+		Issues: map[string][]analysis.Issue{
+			"NoLength": {
+				{Message: "100% of name: a,b\r\nis long", Filename: "app/a:b,c.go", Line: 3, Hash: "c4ca4238a0b923820dcc509a6f75849b", Severity: "MAJOR"},
+			},
+			"NoObject": {
+				{Message: "package file app.go is not found", Filename: "app/util.go", Hash: "5a90af01712c1c2960f886090e112ec6", Severity: "INFO"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, GitHub(&buf, r))
+
+	// `:` and `,` are escaped only in properties, message keeps them.
+	require.Equal(t, "::warning file=app/a%3Ab%2Cc.go,line=3,title=NoLength::100%25 of name: a,b%0D%0Ais long (c4ca4238a0b923820dcc509a6f75849b)\n"+
+		"::notice file=app/util.go,line=1,title=NoObject::package file app.go is not found (5a90af01712c1c2960f886090e112ec6)\n", buf.String())
+}
+
+func TestGetGitHubLevel(t *testing.T) {
+	for severity, level := range map[string]string{
+		"BLOCKER":  "error",
+		"CRITICAL": "error",
+		"MAJOR":    "warning",
+		"MINOR":    "notice",
+		"INFO":     "notice",
+		"":         "error",
+	} {
+		require.Equal(t, level, GetGitHubLevel(severity), severity)
+	}
+}
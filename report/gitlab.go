@@ -0,0 +1,65 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// GitLab write report in format GitLab Code Quality.
+// Please more info in https://docs.gitlab.com/ee/ci/testing/code_quality.html#code-quality-report-format
+func GitLab(w io.Writer, r *Report) error {
+	issues := make([]gitlabIssue, 0)
+
+	// fingerprint must be unique in report, e.g. issues of length and segments of the same name in NoLength.
+	occurrences := analysis.Occurrences{}
+
+	for _, linter := range r.Linters {
+		for _, issue := range r.Issues[linter.Name] {
+			path := filepath.ToSlash(analysis.GetPathRelative(issue.Filename))
+
+			fingerprint := issue.Hash
+			if fingerprint == "" {
+				fingerprint = analysis.GetHashFromString(fmt.Sprintf("%s_%s_%d_%s", linter.Name, path, issue.Line, issue.Message))
+			}
+
+			issues = append(issues, gitlabIssue{
+				Description: issue.Message,
+				CheckName:   linter.Name,
+				Fingerprint: occurrences.Get(fingerprint),
+				Severity:    strings.ToLower(getValueOrDefault(issue.Severity, config.SeverityBlocker)),
+				Location: gitlabLocation{
+					Path:  path,
+					Lines: gitlabLines{Begin: max(issue.Line, 1)},
+				},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(issues)
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLab(t *testing.T) {
+	r := newReport()
+	r.Issues["NoInit"] = []analysis.Issue{{Message: "init is not allowed", Filename: "app/app.go", Line: 7}}
+
+	var buf bytes.Buffer
+	require.NoError(t, GitLab(&buf, r))
+
+	var issues []gitlabIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &issues))
+	require.Len(t, issues, 6)
+
+	var lines []int
+	fingerprints := make(map[string]bool)
+	for _, issue := range issues {
+		lines = append(lines, issue.Location.Lines.Begin)
+		fingerprints[issue.Fingerprint] = true
+	}
+
+	// issue of package without line begins on line 1.
+	require.Equal(t, []int{10, 12, 1, 3, 3, 7}, lines)
+
+	// fingerprints are unique in report, the same hash gets index of occurrence.
+	require.Len(t, fingerprints, 6)
+	require.Equal(t, "9272e16ca4af2a3e3910d95cc9ab6411", issues[0].Fingerprint)
+	require.Equal(t, "c4ca4238a0b923820dcc509a6f75849b", issues[3].Fingerprint)
+
+	// issue without hash gets fingerprint of linter, position and message.
+	require.Equal(t, analysis.GetHashFromString("NoInit_app/app.go_7_init is not allowed"), issues[5].Fingerprint)
+
+	require.Equal(t, gitlabIssue{
+		Description: "package file app.go is not found",
+		CheckName:   "NoObject",
+		Fingerprint: "5a90af01712c1c2960f886090e112ec6",
+		Severity:    "minor",
+		Location:    gitlabLocation{Path: "app/util.go", Lines: gitlabLines{Begin: 1}},
+	}, issues[2])
+}
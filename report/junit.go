@@ -0,0 +1,77 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",cdata"`
+}
+
+// JUnit write report in format JUnit XML: one testsuite per linter and
+// one failed testcase per issue. A linter without issues has one passed testcase.
+func JUnit(w io.Writer, r *Report) error {
+	output := junitTestSuites{Name: "golimiter", Suites: make([]junitTestSuite, 0, len(r.Linters))}
+
+	for _, linter := range r.Linters {
+		suite := junitTestSuite{Name: linter.Name}
+
+		for _, issue := range r.Issues[linter.Name] {
+			position := fmt.Sprintf("%s:%d", analysis.GetPathRelative(issue.Filename), max(issue.Line, 1))
+
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      position,
+				ClassName: linter.Name,
+				Failure: &junitFailure{
+					Message: issue.Message,
+					Type:    getValueOrDefault(issue.Severity, config.SeverityBlocker),
+					Content: fmt.Sprintf("%s: %s\nHash: %s", position, issue.Message, issue.Hash),
+				},
+			})
+		}
+
+		suite.Failures = len(suite.TestCases)
+
+		if suite.Failures == 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{Name: linter.Name, ClassName: linter.Name})
+		}
+
+		suite.Tests = len(suite.TestCases)
+
+		output.Tests += suite.Tests
+		output.Failures += suite.Failures
+		output.Suites = append(output.Suites, suite)
+	}
+
+	return writeXML(w, func(encoder *xml.Encoder) error {
+		return encoder.Encode(output)
+	})
+}
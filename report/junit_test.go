@@ -0,0 +1,7 @@
+package report
+
+import "testing"
+
+func TestJUnit(t *testing.T) {
+	requireGolden(t, "junit.golden.xml", JUnit)
+}
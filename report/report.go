@@ -0,0 +1,63 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+// Report result of analysis prepared for output.
+type Report struct {
+	// Version golimiter linter.
+	Version string
+	// Config used for analysis, may be nil.
+	Config *config.Config
+	// Linters which were run, in order of output.
+	Linters []*analysis.Linter
+	// Issues found by linters, key is name of linter.
+	Issues map[string][]analysis.Issue
+}
+
+// Writer write report in a specific format.
+type Writer func(w io.Writer, r *Report) error
+
+// Formats all supported formats of report.
+var Formats = map[string]Writer{
+	"text":       Text,
+	"json":       JSON,
+	"sarif":      SARIF,
+	"sonar":      Sonar,
+	"checkstyle": Checkstyle,
+	"junit":      JUnit,
+	"gitlab":     GitLab,
+	"github":     GitHub,
+}
+
+// Text write report in human-readable colored format.
+func Text(w io.Writer, r *Report) error {
+	for _, linter := range r.Linters {
+		for _, issue := range r.Issues[linter.Name] {
+			position := fmt.Sprintf("%s:%v", analysis.GetPathRelative(issue.Filename), issue.Line)
+
+			builds := ""
+			if len(issue.Builds) != 0 {
+				builds = fmt.Sprintf(" [%s]", strings.Join(issue.Builds, "; "))
+			}
+
+			_, err := fmt.Fprintf(w, "%s \033[31m%s: %s. \033[0m\033[30m(%s)%s\033[0m\n", position, linter.Name, issue.Message, issue.Hash, builds)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// JSON write issues as json object, key is name of linter.
+func JSON(w io.Writer, r *Report) error {
+	return json.NewEncoder(w).Encode(r.Issues)
+}
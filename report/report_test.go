@@ -0,0 +1,56 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+// newReport return report with issues of several severities and linter without issues,
+// issue without line is issue of package.
+func newReport() *Report {
+	cfg := &config.Config{Linters: map[string]config.LinterSettings{
+		"NoDefer": &config.DefaultLinter{Info: config.Info{EffortMinutes: 5}},
+	}}
+
+	return &Report{
+		Version: "1.0.0",
+		Config:  cfg,
+		Linters: []*analysis.Linter{
+			{Name: "NoDefer", Doc: "check using defer"},
+			{Name: "NoObject", Doc: "check object in package"},
+			{Name: "NoLength", Doc: "check length of identifiers"},
+			{Name: "NoInit", Doc: "check using init"},
+		},
+		Issues: map[string][]analysis.Issue{
+			"NoDefer": {
+				{Message: "defer is not allowed", Filename: "app/app.go", Line: 10, Hash: "9272e16ca4af2a3e3910d95cc9ab6411", Severity: config.SeverityBlocker, Type: config.TypeBug},
+				{Message: "defer is not allowed", Filename: "app/app.go", Line: 12, Hash: "0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1", Severity: config.SeverityMajor, Type: config.TypeCodeSmell, Builds: []string{"linux", "windows"}},
+			},
+			"NoObject": {
+				{Message: "package file app.go is not found", Filename: "app/util.go", Hash: "5a90af01712c1c2960f886090e112ec6", Severity: config.SeverityMinor, Package: true},
+			},
+			"NoLength": {
+				// issues of length and segments of the same name have the same hash.
+				{Message: "length of name is 40 <max 30>", Filename: "app/app.go", Line: 3, Hash: "c4ca4238a0b923820dcc509a6f75849b", Severity: config.SeverityInfo},
+				{Message: "segments of name is 8 <max 5>", Filename: "app/app.go", Line: 3, Hash: "c4ca4238a0b923820dcc509a6f75849b"},
+			},
+		},
+	}
+}
+
+// requireGolden check output of writer for report is equal to golden file of testdata.
+func requireGolden(t *testing.T, golden string, writer Writer) {
+	var buf bytes.Buffer
+	require.NoError(t, writer(&buf, newReport()))
+
+	expected, err := os.ReadFile(filepath.Join("testdata", golden))
+	require.NoError(t, err)
+
+	require.Equal(t, string(expected), buf.String())
+}
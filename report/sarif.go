@@ -0,0 +1,151 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+const (
+	sarifVersion     = "2.1.0"
+	sarifSchema      = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifFingerprint = "golimiter/v1"
+	informationURI   = "https://github.com/mirecl/golimiter"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          *sarifProperties  `json:"properties,omitempty"`
+}
+
+type sarifProperties struct {
+	Builds []string `json:"builds,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// SARIF write report in format SARIF 2.1.0 with one run.
+func SARIF(w io.Writer, r *Report) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "golimiter",
+			Version:        r.Version,
+			InformationURI: informationURI,
+			Rules:          make([]sarifRule, 0, len(r.Linters)),
+		}},
+		Results: make([]sarifResult, 0),
+	}
+
+	// fingerprint must be unique in report, e.g. issues of length and segments of the same name in NoLength.
+	occurrences := analysis.Occurrences{}
+
+	for i, linter := range r.Linters {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               linter.Name,
+			Name:             linter.Name,
+			ShortDescription: sarifMessage{Text: linter.Doc},
+		})
+
+		for _, issue := range r.Issues[linter.Name] {
+			result := sarifResult{
+				RuleID:    linter.Name,
+				RuleIndex: i,
+				Level:     GetSARIFLevel(issue.Severity),
+				Message:   sarifMessage{Text: issue.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{
+							URI:       filepath.ToSlash(analysis.GetPathRelative(issue.Filename)),
+							URIBaseID: "%SRCROOT%",
+						},
+						Region: sarifRegion{StartLine: max(issue.Line, 1)},
+					},
+				}},
+			}
+
+			if issue.Hash != "" {
+				result.PartialFingerprints = map[string]string{sarifFingerprint: occurrences.Get(issue.Hash)}
+			}
+
+			if len(issue.Builds) != 0 {
+				result.Properties = &sarifProperties{Builds: issue.Builds}
+			}
+
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+// GetSARIFLevel mapping severity of issue to level SARIF.
+func GetSARIFLevel(severity string) string {
+	switch severity {
+	case config.SeverityBlocker, config.SeverityCritical:
+		return "error"
+	case config.SeverityMajor:
+		return "warning"
+	case config.SeverityMinor, config.SeverityInfo:
+		return "note"
+	}
+	return "error"
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, SARIF(&buf, newReport()))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+	require.Equal(t, "2.1.0", log.Version)
+	require.Equal(t, "https://json.schemastore.org/sarif-2.1.0.json", log.Schema)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	require.Equal(t, "golimiter", run.Tool.Driver.Name)
+	require.Equal(t, "1.0.0", run.Tool.Driver.Version)
+	require.Equal(t, []sarifRule{
+		{ID: "NoDefer", Name: "NoDefer", ShortDescription: sarifMessage{Text: "check using defer"}},
+		{ID: "NoObject", Name: "NoObject", ShortDescription: sarifMessage{Text: "check object in package"}},
+		{ID: "NoLength", Name: "NoLength", ShortDescription: sarifMessage{Text: "check length of identifiers"}},
+		{ID: "NoInit", Name: "NoInit", ShortDescription: sarifMessage{Text: "check using init"}},
+	}, run.Tool.Driver.Rules)
+
+	var levels, fingerprints []string
+	for _, result := range run.Results {
+		require.Equal(t, result.RuleID, run.Tool.Driver.Rules[result.RuleIndex].ID)
+		levels = append(levels, result.Level)
+		fingerprints = append(fingerprints, result.PartialFingerprints["golimiter/v1"])
+	}
+
+	// BLOCKER, MAJOR, MINOR, INFO and empty severity.
+	require.Equal(t, []string{"error", "warning", "note", "note", "error"}, levels)
+
+	// fingerprints are unique in report, the same hash gets index of occurrence.
+	occurrences := analysis.Occurrences{}
+	occurrences.Get("c4ca4238a0b923820dcc509a6f75849b")
+
+	require.Equal(t, []string{
+		"9272e16ca4af2a3e3910d95cc9ab6411",
+		"0bd56c5b0fc0bd1d4f1d0e8cb4f6f0e1",
+		"5a90af01712c1c2960f886090e112ec6",
+		"c4ca4238a0b923820dcc509a6f75849b",
+		occurrences.Get("c4ca4238a0b923820dcc509a6f75849b"),
+	}, fingerprints)
+
+	location := run.Results[2].Locations[0].PhysicalLocation
+	require.Equal(t, sarifArtifactLocation{URI: "app/util.go", URIBaseID: "%SRCROOT%"}, location.ArtifactLocation)
+	require.Equal(t, 1, location.Region.StartLine)
+
+	require.Equal(t, &sarifProperties{Builds: []string{"linux", "windows"}}, run.Results[1].Properties)
+}
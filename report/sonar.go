@@ -0,0 +1,75 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+)
+
+const sonarEngineID = "golimiter"
+
+type sonarReport struct {
+	Issues []sonarIssue `json:"issues"`
+}
+
+type sonarIssue struct {
+	EngineID        string        `json:"engineId"`
+	RuleID          string        `json:"ruleId"`
+	Severity        string        `json:"severity"`
+	Type            string        `json:"type"`
+	EffortMinutes   int           `json:"effortMinutes,omitempty"`
+	PrimaryLocation sonarLocation `json:"primaryLocation"`
+}
+
+type sonarLocation struct {
+	Message   string         `json:"message"`
+	FilePath  string         `json:"filePath"`
+	TextRange sonarTextRange `json:"textRange"`
+}
+
+type sonarTextRange struct {
+	StartLine int `json:"startLine"`
+}
+
+// Sonar write report in format SonarQube generic issue import.
+// Please more info in https://docs.sonarsource.com/sonarqube/latest/analyzing-source-code/importing-external-issues/generic-issue-import-format/
+func Sonar(w io.Writer, r *Report) error {
+	report := sonarReport{Issues: make([]sonarIssue, 0)}
+
+	for _, linter := range r.Linters {
+		var effort int
+		if r.Config != nil {
+			effort = r.Config.GetInfo(linter.Name).EffortMinutes
+		}
+
+		for _, issue := range r.Issues[linter.Name] {
+			report.Issues = append(report.Issues, sonarIssue{
+				EngineID:      sonarEngineID,
+				RuleID:        linter.Name,
+				Severity:      getValueOrDefault(issue.Severity, config.SeverityBlocker),
+				Type:          getValueOrDefault(issue.Type, config.TypeBug),
+				EffortMinutes: effort,
+				PrimaryLocation: sonarLocation{
+					Message:   issue.Message,
+					FilePath:  filepath.ToSlash(analysis.GetPathRelative(issue.Filename)),
+					TextRange: sonarTextRange{StartLine: max(issue.Line, 1)},
+				},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+func getValueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
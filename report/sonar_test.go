@@ -0,0 +1,7 @@
+package report
+
+import "testing"
+
+func TestSonar(t *testing.T) {
+	requireGolden(t, "sonar.golden.json", Sonar)
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/mirecl/golimiter/linters"
+)
+
+// watchedIssue issue found by linter, key is name of linter and hash of issue (see `getWatchKey`).
+type watchedIssue struct {
+	linter string
+	issue  analysis.Issue
+}
+
+// fileState state of file to detect changes by polling.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher state of watch mode between polls of files.
+type watcher struct {
+	root       string
+	configPath string
+	cfg        *config.Config
+	files      map[string]fileState
+	// issues issues of packages by dir.
+	issues map[string]map[string]watchedIssue
+	// readConfig read config after change of config file.
+	readConfig func() (*config.Config, error)
+	// run run linters for patterns of packages.
+	run func(cfg *config.Config, patterns []string) (map[string][]analysis.Issue, error)
+}
+
+// runWatch poll files of module, re-run linters for changed packages and print
+// newly introduced (+) and fixed (-) issues since the previous run.
+func runWatch(args []string) int {
+	flags := flag.NewFlagSet("golimiter watch", flag.ExitOnError)
+	intervalFlag := flags.Duration("interval", time.Second, "interval of polling files")
+	load := addLoadFlags(flags)
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: golimiter watch [flags]\n\n")
+		fmt.Fprintf(flags.Output(), "Re-run linters for changed packages and print new (+) and fixed (-) issues.\n")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if len(flags.Args()) != 0 {
+		return exitWithError(errors.New("watch analyzes all packages of module, packages are not allowed"))
+	}
+
+	cfg, opts, err := load.load(nil)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	configPath, err := filepath.Abs(*load.config)
+	if err != nil {
+		return exitWithError(err)
+	}
+
+	w := &watcher{
+		root:       config.GetRootDir(),
+		configPath: configPath,
+		cfg:        cfg,
+		issues:     make(map[string]map[string]watchedIssue),
+		readConfig: load.readConfig,
+		run: func(cfg *config.Config, patterns []string) (map[string][]analysis.Issue, error) {
+			opts.Patterns = patterns
+			return analysis.Run(cfg, opts, linters.All...)
+		},
+	}
+
+	if w.files, err = scanGoFiles(w.root, w.configPath); err != nil {
+		return exitWithError(err)
+	}
+
+	w.update(nil)
+
+	for {
+		time.Sleep(*intervalFlag)
+
+		if _, _, err := w.poll(); err != nil {
+			return exitWithError(err)
+		}
+	}
+}
+
+// poll scan files and re-run linters for packages of changed files, all packages are re-run if config is changed.
+// It returns new and fixed issues since the previous run.
+func (w *watcher) poll() ([]watchedIssue, []watchedIssue, error) {
+	current, err := scanGoFiles(w.root, w.configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed := getChangedFiles(w.files, current)
+	w.files = current
+
+	if len(changed) == 0 {
+		return nil, nil, nil
+	}
+
+	// exclusions or settings of linters are changed, so all packages are re-run.
+	if slices.Contains(changed, w.configPath) {
+		// invalid config is reported and the previous config is kept until it is fixed.
+		cfg, err := w.readConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "golimiter: %s\n", err)
+			return nil, nil, nil
+		}
+		w.cfg = cfg
+
+		added, fixed := w.update(nil)
+		return added, fixed, nil
+	}
+
+	var dirs []string
+	for _, path := range changed {
+		if !slices.Contains(dirs, filepath.Dir(path)) {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+	}
+
+	added, fixed := w.update(dirs)
+	return added, fixed, nil
+}
+
+// update run linters for packages in dirs (all packages of module if dirs is nil),
+// print and return new and fixed issues of packages and update issues by dir.
+// If some packages are broken, error is printed and issues are kept until packages are fixed.
+func (w *watcher) update(dirs []string) ([]watchedIssue, []watchedIssue) {
+	patterns := []string{filepath.Join(w.root, "...")}
+
+	if dirs != nil {
+		patterns = nil
+		for _, dir := range dirs {
+			// deleted packages have no issues.
+			if matches, _ := filepath.Glob(filepath.Join(dir, "*.go")); len(matches) != 0 {
+				patterns = append(patterns, dir)
+			}
+		}
+	}
+
+	allIssues := make(map[string][]analysis.Issue)
+
+	if len(patterns) != 0 {
+		var err error
+		if allIssues, err = w.run(w.cfg, patterns); err != nil {
+			fmt.Fprintf(os.Stderr, "golimiter: %s\n", err)
+			return nil, nil
+		}
+	}
+
+	current := make(map[string]map[string]watchedIssue)
+
+	for _, linter := range slices.Sorted(maps.Keys(allIssues)) {
+		for _, issue := range allIssues[linter] {
+			dir := filepath.Dir(issue.Filename)
+			if current[dir] == nil {
+				current[dir] = make(map[string]watchedIssue)
+			}
+			current[dir][getWatchKey(linter, issue)] = watchedIssue{linter: linter, issue: issue}
+		}
+	}
+
+	if dirs == nil {
+		dirs = slices.Collect(maps.Keys(w.issues))
+		for dir := range current {
+			if !slices.Contains(dirs, dir) {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	var added, fixed []watchedIssue
+
+	for _, dir := range dirs {
+		for key, issue := range current[dir] {
+			if _, ok := w.issues[dir][key]; !ok {
+				added = append(added, issue)
+			}
+		}
+
+		for key, issue := range w.issues[dir] {
+			if _, ok := current[dir][key]; !ok {
+				fixed = append(fixed, issue)
+			}
+		}
+
+		if len(current[dir]) == 0 {
+			delete(w.issues, dir)
+		} else {
+			w.issues[dir] = current[dir]
+		}
+	}
+
+	printWatchedIssues("\033[31m+", added)
+	printWatchedIssues("\033[32m-", fixed)
+
+	total := 0
+	for _, dirIssues := range w.issues {
+		total += len(dirIssues)
+	}
+
+	fmt.Fprintf(os.Stderr, "golimiter: %s %d new, %d fixed, %d total issues\n", time.Now().Format(time.TimeOnly), len(added), len(fixed), total)
+
+	return added, fixed
+}
+
+// getWatchKey return key of issue by name of linter and hash, so issue is kept after shift of lines.
+// Issues of package have the same hash in every file, so file and message are part of their key.
+func getWatchKey(linter string, issue analysis.Issue) string {
+	if issue.Package {
+		return fmt.Sprintf("%s_%s_%s_%s", linter, issue.Hash, issue.Filename, issue.Message)
+	}
+	return fmt.Sprintf("%s_%s", linter, issue.Hash)
+}
+
+func printWatchedIssues(prefix string, issues []watchedIssue) {
+	slices.SortFunc(issues, func(a, b watchedIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.issue.Filename, b.issue.Filename),
+			cmp.Compare(a.issue.Line, b.issue.Line),
+			cmp.Compare(a.linter, b.linter),
+		)
+	})
+
+	for _, w := range issues {
+		position := fmt.Sprintf("%s:%v", analysis.GetPathRelative(w.issue.Filename), w.issue.Line)
+		fmt.Printf("%s %s %s: %s. \033[0m\033[30m(%s)\033[0m\n", prefix, position, w.linter, w.issue.Message, w.issue.Hash)
+	}
+}
+
+// scanGoFiles return state of `.go` files of module and config file,
+// dirs ignored by go tool (vendor, testdata, `.` and `_` prefixes) and nested modules are skipped.
+func scanGoFiles(root, configPath string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); path != root && err == nil {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Ext(path) != ".go" && path != configPath {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+
+	return files, err
+}
+
+// getChangedFiles return sorted paths of added, changed and deleted files.
+func getChangedFiles(previous, current map[string]fileState) []string {
+	var changed []string
+
+	for path, state := range current {
+		if previous[path] != state {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mirecl/golimiter/analysis"
+	"github.com/mirecl/golimiter/config"
+	"github.com/stretchr/testify/require"
+)
+
+// runDefers return issue of NoDefer for every line with `defer` in `.go` files of patterns,
+// hash is built from text of line and index of occurrence like fingerprints of linters.
+func runDefers(patterns []string) (map[string][]analysis.Issue, error) {
+	var issues []analysis.Issue
+
+	for _, pattern := range patterns {
+		err := filepath.WalkDir(strings.TrimSuffix(pattern, "..."), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || filepath.Ext(path) != ".go" || (!strings.HasSuffix(pattern, "...") && filepath.Dir(path) != pattern) {
+				return err
+			}
+
+			body, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			occurrences := analysis.Occurrences{}
+
+			scanner := bufio.NewScanner(bytes.NewReader(body))
+			for line := 1; scanner.Scan(); line++ {
+				if strings.Contains(scanner.Text(), "defer") {
+					hash := occurrences.Get(analysis.GetHashFromString(strings.TrimSpace(scanner.Text())))
+					issues = append(issues, analysis.Issue{Filename: path, Line: line, Hash: hash})
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string][]analysis.Issue{"NoDefer": issues}, nil
+}
+
+// writeFile write file, parent dirs are created.
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatcherPoll(t *testing.T) {
+	root := t.TempDir()
+	configPath := filepath.Join(root, ".golimiter.yaml")
+
+	writeFile(t, filepath.Join(root, "app/app.go"), "package app\n\nfunc A() { defer println() }\n")
+
+	var runs [][]string
+
+	w := &watcher{
+		root:       root,
+		configPath: configPath,
+		cfg:        &config.Config{},
+		issues:     make(map[string]map[string]watchedIssue),
+		readConfig: func() (*config.Config, error) { return config.ReadFromFile(configPath) },
+		run: func(_ *config.Config, patterns []string) (map[string][]analysis.Issue, error) {
+			runs = append(runs, patterns)
+			return runDefers(patterns)
+		},
+	}
+
+	var err error
+	w.files, err = scanGoFiles(root, configPath)
+	require.NoError(t, err)
+
+	added, fixed := w.update(nil)
+	require.Len(t, added, 1)
+	require.Empty(t, fixed)
+
+	// files are not changed.
+	added, fixed, err = w.poll()
+	require.NoError(t, err)
+	require.Empty(t, added)
+	require.Empty(t, fixed)
+	require.Len(t, runs, 1)
+
+	// issue of new func is new.
+	writeFile(t, filepath.Join(root, "app/app.go"), "package app\n\nfunc A() { defer println() }\n\nfunc B() { defer println() }\n")
+
+	added, fixed, err = w.poll()
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	require.Equal(t, 5, added[0].issue.Line)
+	require.Empty(t, fixed)
+	require.Equal(t, []string{filepath.Join(root, "app")}, runs[len(runs)-1])
+
+	// identical issue has other hash by index of occurrence.
+	writeFile(t, filepath.Join(root, "app/app.go"), "package app\n\nfunc A() { defer println() }\n\nfunc B() { defer println() }\nfunc B() { defer println() }\n")
+
+	added, fixed, err = w.poll()
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	require.Equal(t, 6, added[0].issue.Line)
+	require.Empty(t, fixed)
+
+	// issue moved down a line is neither new nor fixed.
+	writeFile(t, filepath.Join(root, "app/app.go"), "package app\n\n\nfunc A() { defer println() }\n\nfunc B() { defer println() }\nfunc B() { defer println() }\n")
+
+	added, fixed, err = w.poll()
+	require.NoError(t, err)
+	require.Empty(t, added)
+	require.Empty(t, fixed)
+
+	// issues of deleted package are fixed without run of linters.
+	writeFile(t, filepath.Join(root, "other/other.go"), "package other\n\nfunc C() { defer println() }\n")
+
+	added, _, err = w.poll()
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+
+	require.NoError(t, os.Remove(filepath.Join(root, "other/other.go")))
+
+	count := len(runs)
+	added, fixed, err = w.poll()
+	require.NoError(t, err)
+	require.Empty(t, added)
+	require.Len(t, fixed, 1)
+	require.Len(t, runs, count)
+
+	// invalid config is not applied.
+	cfg := w.cfg
+	writeFile(t, configPath, "global: [\n")
+
+	_, _, err = w.poll()
+	require.NoError(t, err)
+	require.Same(t, cfg, w.cfg)
+	require.Len(t, runs, count)
+
+	// all packages are re-run after change of config.
+	writeFile(t, configPath, "global:\n  ExcludeFiles:\n    - app/legacy.go\n")
+
+	added, fixed, err = w.poll()
+	require.NoError(t, err)
+	require.Empty(t, added)
+	require.Empty(t, fixed)
+	require.NotSame(t, cfg, w.cfg)
+	require.Equal(t, []string{filepath.Join(root, "...")}, runs[len(runs)-1])
+}
+
+func TestGetWatchKey(t *testing.T) {
+	issue := analysis.Issue{Filename: "a.go", Line: 3, Hash: "9272e16ca4af2a3e3910d95cc9ab6411"}
+	moved := analysis.Issue{Filename: "a.go", Line: 4, Hash: "9272e16ca4af2a3e3910d95cc9ab6411"}
+	require.Equal(t, getWatchKey("NoDefer", issue), getWatchKey("NoDefer", moved))
+
+	// issue of package is reported for every file with the same hash.
+	pkgIssue := analysis.Issue{Filename: "a.go", Line: 1, Hash: "951650b78e972315e1261d8aef8849c5", Package: true}
+	otherIssue := analysis.Issue{Filename: "b.go", Line: 1, Hash: "951650b78e972315e1261d8aef8849c5", Package: true}
+	require.NotEqual(t, getWatchKey("NoUnderscore", pkgIssue), getWatchKey("NoUnderscore", otherIssue))
+}